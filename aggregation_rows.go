@@ -0,0 +1,229 @@
+package golastic
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	sorter "sort"
+	"strconv"
+)
+
+// AggregationRow represents a flattened bucket keyed by column
+type AggregationRow map[string]interface{}
+
+// AggregationRows represents the tabular form of an aggregation tree, the columns are ordered
+// by group-by levels first, followed by the doc counts and the metrics
+type AggregationRows struct {
+	Columns []string         `json:"columns"`
+	Rows    []AggregationRow `json:"rows"`
+	groups  []string
+	counts  []string
+	metrics []string
+}
+
+// Rows flattens the aggregation tree into one row per leaf bucket, holding one column for the key of each
+// bucket aggregation level, the doc_count of the leaf bucket and one column for each metric found along the way
+func (ar *AggregationResponses) Rows() *AggregationRows {
+	rows := &AggregationRows{}
+	rows.Rows = rows.flatten(*ar, AggregationRow{})
+	rows.Columns = append(append(append([]string{}, rows.groups...), rows.counts...), rows.metrics...)
+
+	return rows
+}
+
+// WriteCSV writes the rows as CSV including a header with the column names
+func (ar *AggregationRows) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(ar.Columns); err != nil {
+		return err
+	}
+
+	for _, row := range ar.Rows {
+		record := []string{}
+
+		for _, column := range ar.Columns {
+			record = append(record, formatCell(row[column]))
+		}
+
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
+
+// WriteJSONLines writes each row as a JSON object on its own line
+func (ar *AggregationRows) WriteJSONLines(w io.Writer) error {
+	for _, row := range ar.Rows {
+		data, err := json.Marshal(row)
+
+		if err != nil {
+			return err
+		}
+
+		if _, err := w.Write(append(data, '\n')); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (ar *AggregationRows) flatten(items AggregationResponses, row AggregationRow) []AggregationRow {
+	row = row.copy()
+
+	buckets := map[string]*AggregationResponse{}
+	names := []string{}
+
+	for _, item := range inlineSingleBuckets(items, "") {
+		switch {
+		case item.response.Buckets != nil:
+			buckets[item.name] = item.response
+			names = append(names, item.name)
+		case item.response.DocCount != nil:
+			ar.set(row, &ar.metrics, item.name+".doc_count", *item.response.DocCount)
+		default:
+			ar.addMetric(row, item.name, item.response)
+		}
+	}
+
+	rows := []AggregationRow{}
+
+	for _, name := range names {
+		ar.addColumn(&ar.groups, name)
+
+		for _, bucket := range buckets[name].Buckets {
+			bucketRow := row.copy()
+			bucketRow[name] = bucket.Key
+
+			if len(bucket.KeyAsString) > 0 {
+				bucketRow[name] = bucket.KeyAsString
+			}
+
+			ar.set(bucketRow, &ar.counts, "doc_count", bucket.DocCount)
+
+			if bucket.ParentDocCount != nil {
+				ar.set(bucketRow, &ar.counts, "parent_doc_count", *bucket.ParentDocCount)
+			}
+
+			rows = append(rows, ar.flatten(bucket.Items, bucketRow)...)
+		}
+	}
+
+	if len(rows) == 0 && len(row) > 0 {
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+func (ar *AggregationRows) addMetric(row AggregationRow, name string, response *AggregationResponse) {
+	if response.Value != nil {
+		ar.set(row, &ar.metrics, name, *response.Value)
+	}
+
+	keys := []string{}
+
+	for key := range response.Values {
+		keys = append(keys, key)
+	}
+
+	sorter.Strings(keys)
+
+	for _, key := range keys {
+		ar.set(row, &ar.metrics, name+"."+key, response.Values[key])
+	}
+
+	if response.Stats != nil {
+		ar.set(row, &ar.metrics, name+".count", response.Stats.Count)
+		ar.set(row, &ar.metrics, name+".min", response.Stats.Min)
+		ar.set(row, &ar.metrics, name+".max", response.Stats.Max)
+		ar.set(row, &ar.metrics, name+".avg", response.Stats.Avg)
+		ar.set(row, &ar.metrics, name+".sum", response.Stats.Sum)
+
+		if response.Stats.StdDeviation != nil {
+			ar.set(row, &ar.metrics, name+".variance", response.Stats.Variance)
+			ar.set(row, &ar.metrics, name+".std_deviation", response.Stats.StdDeviation)
+		}
+	}
+
+	if response.Centroid != nil {
+		ar.set(row, &ar.metrics, name+".lat", response.Centroid.Lat)
+		ar.set(row, &ar.metrics, name+".lon", response.Centroid.Lon)
+	}
+}
+
+type namedResponse struct {
+	name     string
+	response *AggregationResponse
+}
+
+// inlineSingleBuckets lists the responses sorted by name, the content of single bucket
+// aggregations such as nested or filter is inlined under the name of the aggregation
+func inlineSingleBuckets(items AggregationResponses, prefix string) []*namedResponse {
+	names := []string{}
+
+	for name, item := range items {
+		if item != nil {
+			names = append(names, name)
+		}
+	}
+
+	sorter.Strings(names)
+
+	responses := []*namedResponse{}
+
+	for _, name := range names {
+		responses = append(responses, &namedResponse{name: prefix + name, response: items[name]})
+
+		if items[name].Buckets == nil && items[name].DocCount != nil {
+			responses = append(responses, inlineSingleBuckets(items[name].Items, prefix+name+".")...)
+		}
+	}
+
+	return responses
+}
+
+func (ar *AggregationRows) set(row AggregationRow, columns *[]string, column string, value interface{}) {
+	ar.addColumn(columns, column)
+
+	row[column] = value
+}
+
+func (ar *AggregationRows) addColumn(columns *[]string, column string) {
+	if !inSlice(column, *columns...) {
+		*columns = append(*columns, column)
+	}
+}
+
+func (row AggregationRow) copy() AggregationRow {
+	copied := AggregationRow{}
+
+	for column, value := range row {
+		copied[column] = value
+	}
+
+	return copied
+}
+
+func formatCell(value interface{}) string {
+	switch cell := value.(type) {
+	case nil:
+		return ""
+	case *float64:
+		if cell == nil {
+			return ""
+		}
+
+		return strconv.FormatFloat(*cell, 'f', -1, 64)
+	case float64:
+		return strconv.FormatFloat(cell, 'f', -1, 64)
+	}
+
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,125 @@
+package golastic
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	elastic "github.com/alejandro-carstens/elasticfork"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregationRows(t *testing.T) {
+	builder := new(Builder)
+	builder.GroupBy("category", "brand").Stats("price")
+	builder.GroupByField("brand").Metric("avg_price", AvgAgg("price"))
+
+	raw := `{
+		"category": {
+			"buckets": [
+				{
+					"key": "shoes",
+					"doc_count": 3,
+					"brand": {"buckets": [
+						{"key": "acme", "doc_count": 2, "avg_price": {"value": 50}},
+						{"key": "zeta", "doc_count": 1, "avg_price": {"value": 20.5}}
+					]}
+				},
+				{"key": "hats", "doc_count": 1, "brand": {"buckets": []}}
+			]
+		},
+		"price": {"count": 4, "min": 10, "max": 80, "avg": 40, "sum": 160}
+	}`
+
+	aggregations := elastic.Aggregations{}
+
+	assert.Nil(t, json.Unmarshal([]byte(raw), &aggregations))
+
+	response, err := builder.processAggregations(aggregations)
+
+	assert.Nil(t, err)
+
+	rows := response.Rows()
+
+	assert.Equal(
+		t,
+		[]string{"category", "brand", "doc_count", "price.count", "price.min", "price.max", "price.avg", "price.sum", "avg_price"},
+		rows.Columns,
+	)
+	assert.Equal(t, 3, len(rows.Rows))
+	assert.Equal(t, "shoes", rows.Rows[0]["category"])
+	assert.Equal(t, "acme", rows.Rows[0]["brand"])
+	assert.Equal(t, 2, rows.Rows[0]["doc_count"])
+	assert.Equal(t, float64(50), rows.Rows[0]["avg_price"])
+	assert.Equal(t, "hats", rows.Rows[2]["category"])
+	assert.Nil(t, rows.Rows[2]["brand"])
+	assert.Equal(t, 1, rows.Rows[2]["doc_count"])
+
+	csv := new(bytes.Buffer)
+
+	assert.Nil(t, rows.WriteCSV(csv))
+	assert.Equal(
+		t,
+		"category,brand,doc_count,price.count,price.min,price.max,price.avg,price.sum,avg_price\n"+
+			"shoes,acme,2,4,10,80,40,160,50\n"+
+			"shoes,zeta,1,4,10,80,40,160,20.5\n"+
+			"hats,,1,4,10,80,40,160,\n",
+		csv.String(),
+	)
+
+	lines := new(bytes.Buffer)
+
+	assert.Nil(t, rows.WriteJSONLines(lines))
+
+	decoded := []map[string]interface{}{}
+	decoder := json.NewDecoder(lines)
+
+	for decoder.More() {
+		row := map[string]interface{}{}
+
+		assert.Nil(t, decoder.Decode(&row))
+
+		decoded = append(decoded, row)
+	}
+
+	assert.Equal(t, 3, len(decoded))
+	assert.Equal(t, "zeta", decoded[1]["brand"])
+	assert.Equal(t, 20.5, decoded[1]["avg_price"])
+}
+
+func TestAggregationRowsSingleBuckets(t *testing.T) {
+	builder := new(Builder)
+	builder.Aggregation("cast", NestedAgg("cast").SubAggregation(
+		"directors",
+		TermsAgg("cast.director").SubAggregation("per_year", DateHistogramAgg("released_at", "year")),
+	))
+
+	raw := `{
+		"cast": {
+			"doc_count": 8,
+			"directors": {"buckets": [{
+				"key": "James Cameron",
+				"doc_count": 4,
+				"per_year": {"buckets": [{"key": 1230768000000, "key_as_string": "2009", "doc_count": 1}]}
+			}]}
+		}
+	}`
+
+	aggregations := elastic.Aggregations{}
+
+	assert.Nil(t, json.Unmarshal([]byte(raw), &aggregations))
+
+	response, err := builder.processAggregations(aggregations)
+
+	assert.Nil(t, err)
+
+	rows := response.Rows()
+
+	assert.Equal(t, []string{"cast.directors", "per_year", "doc_count", "cast.doc_count"}, rows.Columns)
+	assert.Equal(t, AggregationRow{
+		"cast.doc_count": 8,
+		"cast.directors": "James Cameron",
+		"per_year":       "2009",
+		"doc_count":      1,
+	}, rows.Rows[0])
+}
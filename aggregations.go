@@ -0,0 +1,1108 @@
+package golastic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/Jeffail/gabs"
+	elastic "github.com/alejandro-carstens/elasticfork"
+)
+
+var aggregationOptions = map[string][]string{
+	"terms":             {"size", "min_doc_count", "order", "missing"},
+	"significant_terms": {"size", "min_doc_count"},
+	"histogram":         {"min_doc_count", "extended_bounds", "order", "missing"},
+	"date_histogram":    {"min_doc_count", "extended_bounds", "order", "missing", "format", "time_zone"},
+	"range":             {"ranges", "missing"},
+	"date_range":        {"ranges", "format", "time_zone"},
+	"geo_distance":      {"ranges"},
+	"filters":           {"filters"},
+	"missing":           {},
+	"filter":            {},
+	"nested":            {},
+	"reverse_nested":    {},
+	"geohash_grid":      {"size"},
+	"avg":               {"missing"},
+	"sum":               {"missing"},
+	"min":               {"missing"},
+	"max":               {"missing"},
+	"value_count":       {},
+	"cardinality":       {"missing", "precision_threshold"},
+	"percentiles":       {"missing"},
+	"percentile_ranks":  {"missing"},
+	"top_hits":          {"size", "order", "select"},
+	"geo_bounds":        {},
+	"geo_centroid":      {},
+	"stats":             {"missing"},
+	"extended_stats":    {"missing"},
+	"bucket_sort":       {"order", "size", "from", "gap_policy"},
+	"bucket_selector":   {"gap_policy"},
+	"bucket_script":     {"gap_policy"},
+	"derivative":        {"gap_policy"},
+	"cumulative_sum":    {},
+	"moving_fn":         {"gap_policy"},
+	"avg_bucket":        {"gap_policy"},
+	"max_bucket":        {"gap_policy"},
+}
+
+// Aggregation represents the struct in charge of configuring an aggregation,
+// bucket aggregations can be nested to any depth through SubAggregation
+type Aggregation struct {
+	name               string
+	aggType            string
+	field              string
+	interval           interface{}
+	format             string
+	timeZone           string
+	minDocCount        *int64
+	extendedBounds     []interface{}
+	ranges             []*aggregationRange
+	filters            []*aggregationFilter
+	percents           []float64
+	size               *int
+	sorts              []*sort
+	includes           []string
+	precisionThreshold *int64
+	precision          int
+	origin             GeoPoint
+	unit               string
+	missing            interface{}
+	bucketsPaths       map[string]string
+	script             string
+	window             int
+	from               *int
+	gapPolicy          string
+	options            []string
+	subAggregations    []*Aggregation
+}
+
+type aggregationRange struct {
+	Key  string
+	From interface{}
+	To   interface{}
+}
+
+type aggregationFilter struct {
+	Name   string
+	Filter *Builder
+}
+
+// TermsAgg creates a terms aggregation which buckets the documents by the distinct values of a field
+func TermsAgg(field string) *Aggregation {
+	return newAggregation("terms", field)
+}
+
+// SignificantTermsAgg creates a significant_terms aggregation which buckets the
+// documents by the values of a field that are unusually frequent within the query
+func SignificantTermsAgg(field string) *Aggregation {
+	return newAggregation("significant_terms", field)
+}
+
+// HistogramAgg creates a histogram aggregation which buckets the numeric values of a field by the given interval
+func HistogramAgg(field string, interval float64) *Aggregation {
+	aggregation := newAggregation("histogram", field)
+	aggregation.interval = interval
+
+	return aggregation
+}
+
+// DateHistogramAgg creates a date_histogram aggregation which buckets
+// the values of a date field by the given interval, i.e. "day" or "1h"
+func DateHistogramAgg(field string, interval string) *Aggregation {
+	aggregation := newAggregation("date_histogram", field)
+	aggregation.interval = interval
+
+	return aggregation
+}
+
+// RangeAgg creates a range aggregation, use Range for adding the ranges
+func RangeAgg(field string) *Aggregation {
+	return newAggregation("range", field)
+}
+
+// DateRangeAgg creates a date_range aggregation, use Range for adding the ranges
+// which can be expressed as dates or date math expressions
+func DateRangeAgg(field string) *Aggregation {
+	return newAggregation("date_range", field)
+}
+
+// GeoDistanceAgg creates a geo_distance aggregation which buckets the documents by their distance
+// to the given origin expressed in the given unit, use Range for adding the distance rings
+func GeoDistanceAgg(field string, origin GeoPoint, unit string) *Aggregation {
+	aggregation := newAggregation("geo_distance", field)
+	aggregation.origin = origin
+	aggregation.unit = unit
+
+	return aggregation
+}
+
+// FiltersAgg creates a filters aggregation, use Filter for adding the named buckets
+func FiltersAgg() *Aggregation {
+	return newAggregation("filters", "")
+}
+
+// FilterAgg creates a single bucket filter aggregation holding the
+// documents that match the clauses specified on the filter callback
+func FilterAgg(filter func(*Builder)) *Aggregation {
+	aggregation := newAggregation("filter", "")
+	builder := new(Builder)
+
+	if filter != nil {
+		filter(builder)
+	}
+
+	aggregation.filters = []*aggregationFilter{{Filter: builder}}
+
+	return aggregation
+}
+
+// NestedAgg creates a single bucket nested aggregation which aggregates the nested objects found
+// at the given path, the sub-aggregations refer to the nested fields through the object.property notation
+func NestedAgg(path string) *Aggregation {
+	return newAggregation("nested", path)
+}
+
+// ReverseNestedAgg creates a single bucket reverse_nested aggregation which joins back from the nested objects
+// to their parent documents, an empty path joins back to the root documents
+func ReverseNestedAgg(path string) *Aggregation {
+	return newAggregation("reverse_nested", path)
+}
+
+// MissingAgg creates a missing aggregation which buckets the documents lacking a value for a field
+func MissingAgg(field string) *Aggregation {
+	return newAggregation("missing", field)
+}
+
+// GeoHashGridAgg creates a geohash_grid aggregation with the given precision (1 to 12)
+func GeoHashGridAgg(field string, precision int) *Aggregation {
+	aggregation := newAggregation("geohash_grid", field)
+	aggregation.precision = precision
+
+	return aggregation
+}
+
+// AvgAgg creates an avg aggregation
+func AvgAgg(field string) *Aggregation {
+	return newAggregation("avg", field)
+}
+
+// SumAgg creates a sum aggregation
+func SumAgg(field string) *Aggregation {
+	return newAggregation("sum", field)
+}
+
+// MinAgg creates a min aggregation
+func MinAgg(field string) *Aggregation {
+	return newAggregation("min", field)
+}
+
+// MaxAgg creates a max aggregation
+func MaxAgg(field string) *Aggregation {
+	return newAggregation("max", field)
+}
+
+// ValueCountAgg creates a value_count aggregation
+func ValueCountAgg(field string) *Aggregation {
+	return newAggregation("value_count", field)
+}
+
+// StatsAgg creates a stats aggregation returning the count, min, max, avg and sum of a field
+func StatsAgg(field string) *Aggregation {
+	return newAggregation("stats", field)
+}
+
+// ExtendedStatsAgg creates an extended_stats aggregation which adds
+// the variance and standard deviation to the stats of a field
+func ExtendedStatsAgg(field string) *Aggregation {
+	return newAggregation("extended_stats", field)
+}
+
+// CardinalityAgg creates a cardinality aggregation which approximates the number of distinct values of a field
+func CardinalityAgg(field string) *Aggregation {
+	return newAggregation("cardinality", field)
+}
+
+// PercentilesAgg creates a percentiles aggregation, the default percentiles
+// are used when none are specified
+func PercentilesAgg(field string, percents ...float64) *Aggregation {
+	aggregation := newAggregation("percentiles", field)
+	aggregation.percents = percents
+
+	return aggregation
+}
+
+// PercentileRanksAgg creates a percentile_ranks aggregation for the given values
+func PercentileRanksAgg(field string, values ...float64) *Aggregation {
+	aggregation := newAggregation("percentile_ranks", field)
+	aggregation.percents = values
+
+	return aggregation
+}
+
+// TopHitsAgg creates a top_hits aggregation returning the top documents of each bucket
+func TopHitsAgg(size int) *Aggregation {
+	return newAggregation("top_hits", "").Size(size)
+}
+
+// GeoBoundsAgg creates a geo_bounds aggregation
+func GeoBoundsAgg(field string) *Aggregation {
+	return newAggregation("geo_bounds", field)
+}
+
+// GeoCentroidAgg creates a geo_centroid aggregation
+func GeoCentroidAgg(field string) *Aggregation {
+	return newAggregation("geo_centroid", field)
+}
+
+// Aggregation adds the given aggregation to the query under the specified name
+func (b *Builder) Aggregation(name string, aggregation *Aggregation) *Builder {
+	aggregation.name = name
+
+	b.aggregations = append(b.aggregations, aggregation)
+
+	return b
+}
+
+func (b *Builder) findAggregation(name string) *Aggregation {
+	for _, aggregation := range b.aggregations {
+		if aggregation.name == name {
+			return aggregation
+		}
+	}
+
+	return nil
+}
+
+func newAggregation(aggType string, field string) *Aggregation {
+	return &Aggregation{aggType: aggType, field: field}
+}
+
+// SubAggregation nests the given aggregation under each of the buckets of the aggregation
+func (a *Aggregation) SubAggregation(name string, aggregation *Aggregation) *Aggregation {
+	aggregation.name = name
+
+	a.subAggregations = append(a.subAggregations, aggregation)
+
+	return a
+}
+
+// Range adds a bucket for the values greater or equal to from and lesser than to,
+// a nil from or to leaves the range unbounded and the key is optional
+func (a *Aggregation) Range(key string, from interface{}, to interface{}) *Aggregation {
+	a.ranges = append(a.ranges, &aggregationRange{Key: key, From: from, To: to})
+
+	return a.option("ranges")
+}
+
+// Filter adds a named bucket holding the documents that match
+// the clauses specified on the filter callback
+func (a *Aggregation) Filter(name string, filter func(*Builder)) *Aggregation {
+	builder := new(Builder)
+
+	if filter != nil {
+		filter(builder)
+	}
+
+	a.filters = append(a.filters, &aggregationFilter{Name: name, Filter: builder})
+
+	return a.option("filters")
+}
+
+// Size sets the number of buckets or hits to be returned
+func (a *Aggregation) Size(size int) *Aggregation {
+	a.size = &size
+
+	return a.option("size")
+}
+
+// MinDocCount sets the minimum number of documents a bucket needs in order to be returned
+func (a *Aggregation) MinDocCount(minDocCount int64) *Aggregation {
+	a.minDocCount = &minDocCount
+
+	return a.option("min_doc_count")
+}
+
+// ExtendedBounds forces the histogram to return buckets from min to max even if they are empty
+func (a *Aggregation) ExtendedBounds(min interface{}, max interface{}) *Aggregation {
+	a.extendedBounds = []interface{}{min, max}
+
+	return a.option("extended_bounds")
+}
+
+// OrderBy sorts the buckets by "_key", "_count" or a sub-aggregation name, for
+// top_hits aggregations it sorts the returned hits by the given field
+func (a *Aggregation) OrderBy(field string, asc bool) *Aggregation {
+	a.sorts = append(a.sorts, &sort{Field: field, Order: asc})
+
+	return a.option("order")
+}
+
+// Select sets the source fields to be returned by a top_hits aggregation
+func (a *Aggregation) Select(fields ...string) *Aggregation {
+	a.includes = append(a.includes, fields...)
+
+	return a.option("select")
+}
+
+// Format sets the format of the dates returned as key_as_string
+func (a *Aggregation) Format(format string) *Aggregation {
+	a.format = format
+
+	return a.option("format")
+}
+
+// TimeZone sets the time zone used for bucketing dates, i.e. "-05:00" or "America/New_York"
+func (a *Aggregation) TimeZone(timeZone string) *Aggregation {
+	a.timeZone = timeZone
+
+	return a.option("time_zone")
+}
+
+// Missing sets the value used for the documents lacking a value for the aggregated field
+func (a *Aggregation) Missing(value interface{}) *Aggregation {
+	a.missing = value
+
+	return a.option("missing")
+}
+
+// PrecisionThreshold sets the count below which a cardinality aggregation is expected to be close to accurate
+func (a *Aggregation) PrecisionThreshold(threshold int64) *Aggregation {
+	a.precisionThreshold = &threshold
+
+	return a.option("precision_threshold")
+}
+
+func (a *Aggregation) option(option string) *Aggregation {
+	if !inSlice(option, a.options...) {
+		a.options = append(a.options, option)
+	}
+
+	return a
+}
+
+func (a *Aggregation) isBucketAggregation() bool {
+	return inSlice(
+		a.aggType,
+		"terms",
+		"significant_terms",
+		"histogram",
+		"date_histogram",
+		"range",
+		"date_range",
+		"geo_distance",
+		"filters",
+		"filter",
+		"missing",
+		"nested",
+		"reverse_nested",
+		"geohash_grid",
+	)
+}
+
+func (a *Aggregation) isSingleBucketAggregation() bool {
+	return inSlice(a.aggType, "filter", "missing", "nested", "reverse_nested")
+}
+
+func (a *Aggregation) isSingleValueAggregation() bool {
+	return inSlice(
+		a.aggType,
+		"avg",
+		"sum",
+		"min",
+		"max",
+		"value_count",
+		"cardinality",
+		"derivative",
+		"cumulative_sum",
+		"moving_fn",
+		"bucket_script",
+		"avg_bucket",
+		"max_bucket",
+	)
+}
+
+func (a *Aggregation) validate() error {
+	if len(a.name) == 0 {
+		return errors.New("name cannot be empty")
+	}
+
+	if len(a.field) == 0 && a.aggType == "nested" {
+		return errors.New("path cannot be empty")
+	}
+
+	if len(a.field) == 0 && !inSlice(a.aggType, "filters", "filter", "reverse_nested", "top_hits") && !a.isPipelineAggregation() {
+		return errors.New("field cannot be empty")
+	}
+
+	allowed, valid := aggregationOptions[a.aggType]
+
+	if !valid {
+		return errors.New("The aggregation type " + a.aggType + " is invalid.")
+	}
+
+	for _, option := range a.options {
+		if !inSlice(option, allowed...) {
+			return errors.New("The " + option + " option is not supported by " + a.aggType + " aggregations.")
+		}
+	}
+
+	if err := a.validateSettings(); err != nil {
+		return err
+	}
+
+	if len(a.subAggregations) > 0 && !a.isBucketAggregation() {
+		return errors.New("Only bucket aggregations support sub-aggregations.")
+	}
+
+	names := []string{}
+
+	for _, subAggregation := range a.subAggregations {
+		if inSlice(subAggregation.name, names...) {
+			return errors.New("The sub-aggregation name " + subAggregation.name + " is duplicated.")
+		}
+
+		if err := subAggregation.validate(); err != nil {
+			return err
+		}
+
+		names = append(names, subAggregation.name)
+	}
+
+	return validatePipelines(a.subAggregations, a)
+}
+
+func (a *Aggregation) validateSettings() error {
+	if a.size != nil && *a.size <= 0 {
+		return errors.New("The aggregation size needs to be greater than 0.")
+	}
+
+	if a.minDocCount != nil && *a.minDocCount < 0 {
+		return errors.New("The min doc count cannot be negative.")
+	}
+
+	if a.precisionThreshold != nil && *a.precisionThreshold < 0 {
+		return errors.New("The precision threshold cannot be negative.")
+	}
+
+	if a.isPipelineAggregation() {
+		return a.validatePipelineSettings()
+	}
+
+	switch a.aggType {
+	case "histogram":
+		if interval, _ := a.interval.(float64); interval <= 0 {
+			return errors.New("The histogram interval needs to be greater than 0.")
+		}
+
+		for _, bound := range a.extendedBounds {
+			if !isNumeric(bound) {
+				return errors.New("The histogram extended bounds need to be numeric.")
+			}
+		}
+	case "date_histogram":
+		if interval, _ := a.interval.(string); len(interval) == 0 {
+			return errors.New("interval cannot be empty")
+		}
+	case "range", "date_range", "geo_distance":
+		if len(a.ranges) == 0 {
+			return errors.New("Please specify at least a range.")
+		}
+
+		for _, r := range a.ranges {
+			if r.From == nil && r.To == nil {
+				return errors.New("A range needs at least a from or a to value.")
+			}
+		}
+
+		if a.aggType == "geo_distance" {
+			if len(a.unit) > 0 && !inSlice(a.unit, "mi", "yd", "ft", "in", "km", "m", "cm", "mm", "nmi") {
+				return errors.New("The distance unit is invalid.")
+			}
+
+			return a.origin.validate()
+		}
+	case "filter":
+		return a.filters[0].Filter.validateMustClauses()
+	case "filters":
+		if len(a.filters) == 0 {
+			return errors.New("Please specify at least a filter.")
+		}
+
+		for _, filter := range a.filters {
+			if len(filter.Name) == 0 {
+				return errors.New("name cannot be empty")
+			}
+
+			if err := filter.Filter.validateMustClauses(); err != nil {
+				return err
+			}
+		}
+	case "percentiles", "percentile_ranks":
+		if a.aggType == "percentile_ranks" && len(a.percents) == 0 {
+			return errors.New("Please specify at least a value.")
+		}
+
+		for _, percent := range a.percents {
+			if a.aggType == "percentiles" && (percent < 0 || percent > 100) {
+				return errors.New("The percentiles need to be between 0 and 100.")
+			}
+		}
+	case "geohash_grid":
+		if a.precision < 1 || a.precision > 12 {
+			return errors.New("The geohash precision needs to be between 1 and 12.")
+		}
+	}
+
+	return nil
+}
+
+func (a *Aggregation) aggregation() elastic.Aggregation {
+	subAggregations := map[string]elastic.Aggregation{}
+
+	for _, subAggregation := range a.subAggregations {
+		subAggregations[subAggregation.name] = subAggregation.aggregation()
+	}
+
+	if len(subAggregations) == 0 {
+		return a.baseAggregation()
+	}
+
+	return &nestedAggregations{
+		aggregation:     a.baseAggregation(),
+		subAggregations: subAggregations,
+	}
+}
+
+func (a *Aggregation) baseAggregation() elastic.Aggregation {
+	if a.isPipelineAggregation() {
+		return a.pipelineAggregation()
+	}
+
+	switch a.aggType {
+	case "terms":
+		aggregation := elastic.NewTermsAggregation().Field(a.field)
+
+		if a.size != nil {
+			aggregation = aggregation.Size(*a.size)
+		}
+
+		if a.minDocCount != nil {
+			aggregation = aggregation.MinDocCount(int(*a.minDocCount))
+		}
+
+		for _, sort := range a.sorts {
+			aggregation = aggregation.Order(sort.Field, sort.Order)
+		}
+
+		if a.missing != nil {
+			aggregation = aggregation.Missing(a.missing)
+		}
+
+		return aggregation
+	case "significant_terms":
+		aggregation := elastic.NewSignificantTermsAggregation().Field(a.field)
+
+		if a.size != nil {
+			aggregation = aggregation.RequiredSize(*a.size)
+		}
+
+		if a.minDocCount != nil {
+			aggregation = aggregation.MinDocCount(int(*a.minDocCount))
+		}
+
+		return aggregation
+	case "histogram":
+		aggregation := elastic.NewHistogramAggregation().Field(a.field).Interval(a.interval.(float64))
+
+		if a.minDocCount != nil {
+			aggregation = aggregation.MinDocCount(*a.minDocCount)
+		}
+
+		if len(a.extendedBounds) == 2 {
+			min, _ := toFloat(a.extendedBounds[0])
+			max, _ := toFloat(a.extendedBounds[1])
+
+			aggregation = aggregation.ExtendedBounds(min, max)
+		}
+
+		for _, sort := range a.sorts {
+			aggregation = aggregation.Order(sort.Field, sort.Order)
+		}
+
+		if a.missing != nil {
+			aggregation = aggregation.Missing(a.missing)
+		}
+
+		return aggregation
+	case "date_histogram":
+		aggregation := elastic.NewDateHistogramAggregation().Field(a.field).Interval(a.interval.(string))
+
+		if a.minDocCount != nil {
+			aggregation = aggregation.MinDocCount(*a.minDocCount)
+		}
+
+		if len(a.extendedBounds) == 2 {
+			aggregation = aggregation.ExtendedBounds(a.extendedBounds[0], a.extendedBounds[1])
+		}
+
+		for _, sort := range a.sorts {
+			aggregation = aggregation.Order(sort.Field, sort.Order)
+		}
+
+		if a.missing != nil {
+			aggregation = aggregation.Missing(a.missing)
+		}
+
+		if len(a.format) > 0 {
+			aggregation = aggregation.Format(a.format)
+		}
+
+		if len(a.timeZone) > 0 {
+			aggregation = aggregation.TimeZone(a.timeZone)
+		}
+
+		return aggregation
+	case "range":
+		aggregation := elastic.NewRangeAggregation().Field(a.field)
+
+		for _, r := range a.ranges {
+			aggregation = aggregation.AddRangeWithKey(r.Key, r.From, r.To)
+		}
+
+		if a.missing != nil {
+			aggregation = aggregation.Missing(a.missing)
+		}
+
+		return aggregation
+	case "date_range":
+		aggregation := elastic.NewDateRangeAggregation().Field(a.field)
+
+		for _, r := range a.ranges {
+			aggregation = aggregation.AddRangeWithKey(r.Key, r.From, r.To)
+		}
+
+		if len(a.format) > 0 {
+			aggregation = aggregation.Format(a.format)
+		}
+
+		if len(a.timeZone) > 0 {
+			aggregation = aggregation.TimeZone(a.timeZone)
+		}
+
+		return aggregation
+	case "geo_distance":
+		aggregation := elastic.NewGeoDistanceAggregation().
+			Field(a.field).
+			Point(fmt.Sprintf("%v,%v", a.origin.Lat, a.origin.Lon))
+
+		if len(a.unit) > 0 {
+			aggregation = aggregation.Unit(a.unit)
+		}
+
+		for _, r := range a.ranges {
+			aggregation = aggregation.AddRangeWithKey(r.Key, r.From, r.To)
+		}
+
+		return aggregation
+	case "filters":
+		aggregation := elastic.NewFiltersAggregation()
+
+		for _, filter := range a.filters {
+			aggregation = aggregation.FilterWithName(filter.Name, filter.Filter.query())
+		}
+
+		return aggregation
+	case "stats":
+		aggregation := elastic.NewStatsAggregation().Field(a.field)
+
+		if a.missing != nil {
+			aggregation = aggregation.Missing(a.missing)
+		}
+
+		return aggregation
+	case "extended_stats":
+		aggregation := elastic.NewExtendedStatsAggregation().Field(a.field)
+
+		if a.missing != nil {
+			aggregation = aggregation.Missing(a.missing)
+		}
+
+		return aggregation
+	case "filter":
+		return elastic.NewFilterAggregation().Filter(a.filters[0].Filter.query())
+	case "missing":
+		return elastic.NewMissingAggregation().Field(a.field)
+	case "nested":
+		return elastic.NewNestedAggregation().Path(a.field)
+	case "reverse_nested":
+		aggregation := elastic.NewReverseNestedAggregation()
+
+		if len(a.field) > 0 {
+			aggregation = aggregation.Path(a.field)
+		}
+
+		return aggregation
+	case "geohash_grid":
+		aggregation := elastic.NewGeoHashGridAggregation().Field(a.field).Precision(a.precision)
+
+		if a.size != nil {
+			aggregation = aggregation.Size(*a.size)
+		}
+
+		return aggregation
+	case "avg":
+		aggregation := elastic.NewAvgAggregation().Field(a.field)
+
+		if a.missing != nil {
+			aggregation = aggregation.Missing(a.missing)
+		}
+
+		return aggregation
+	case "sum":
+		aggregation := elastic.NewSumAggregation().Field(a.field)
+
+		if a.missing != nil {
+			aggregation = aggregation.Missing(a.missing)
+		}
+
+		return aggregation
+	case "min":
+		aggregation := elastic.NewMinAggregation().Field(a.field)
+
+		if a.missing != nil {
+			aggregation = aggregation.Missing(a.missing)
+		}
+
+		return aggregation
+	case "max":
+		aggregation := elastic.NewMaxAggregation().Field(a.field)
+
+		if a.missing != nil {
+			aggregation = aggregation.Missing(a.missing)
+		}
+
+		return aggregation
+	case "value_count":
+		return elastic.NewValueCountAggregation().Field(a.field)
+	case "cardinality":
+		aggregation := elastic.NewCardinalityAggregation().Field(a.field)
+
+		if a.precisionThreshold != nil {
+			aggregation = aggregation.PrecisionThreshold(*a.precisionThreshold)
+		}
+
+		if a.missing != nil {
+			aggregation = aggregation.Missing(a.missing)
+		}
+
+		return aggregation
+	case "percentiles":
+		aggregation := elastic.NewPercentilesAggregation().Field(a.field)
+
+		if len(a.percents) > 0 {
+			aggregation = aggregation.Percentiles(a.percents...)
+		}
+
+		if a.missing != nil {
+			aggregation = aggregation.Missing(a.missing)
+		}
+
+		return aggregation
+	case "percentile_ranks":
+		aggregation := elastic.NewPercentileRanksAggregation().Field(a.field).Values(a.percents...)
+
+		if a.missing != nil {
+			aggregation = aggregation.Missing(a.missing)
+		}
+
+		return aggregation
+	case "top_hits":
+		aggregation := elastic.NewTopHitsAggregation().Size(*a.size)
+
+		for _, sort := range a.sorts {
+			aggregation = aggregation.Sort(sort.Field, sort.Order)
+		}
+
+		if len(a.includes) > 0 {
+			aggregation = aggregation.FetchSourceContext(elastic.NewFetchSourceContext(true).Include(a.includes...))
+		}
+
+		return aggregation
+	case "geo_bounds":
+		return elastic.NewGeoBoundsAggregation().Field(a.field)
+	}
+
+	return elastic.NewGeoCentroidAggregation().Field(a.field)
+}
+
+func (a *Aggregation) parse(container *gabs.Container) (*AggregationResponse, error) {
+	response := &AggregationResponse{}
+
+	switch a.aggType {
+	case "avg", "sum", "min", "max", "value_count", "cardinality":
+		response.Value = parseFloat(container, "value")
+		response.ValueAsString, _ = container.Path("value_as_string").Data().(string)
+
+		return response, nil
+	case "derivative", "cumulative_sum", "moving_fn", "bucket_script", "avg_bucket", "max_bucket":
+		response.Value = parseFloat(container, "value")
+		response.ValueAsString, _ = container.Path("value_as_string").Data().(string)
+		response.Keys, _ = container.Path("keys").Data().([]interface{})
+
+		return response, nil
+	case "percentiles", "percentile_ranks":
+		values, err := container.Path("values").ChildrenMap()
+
+		if err != nil {
+			return nil, err
+		}
+
+		response.Values = map[string]*float64{}
+
+		for key, value := range values {
+			if number, valid := value.Data().(float64); valid {
+				response.Values[key] = &number
+				continue
+			}
+
+			response.Values[key] = nil
+		}
+
+		return response, nil
+	case "top_hits":
+		hits, err := container.Path("hits.hits").Children()
+
+		if err != nil {
+			return nil, err
+		}
+
+		response.Hits = []json.RawMessage{}
+
+		for _, hit := range hits {
+			response.Hits = append(response.Hits, json.RawMessage(hit.Path("_source").Bytes()))
+		}
+
+		return response, nil
+	case "geo_bounds":
+		if !container.Exists("bounds") {
+			return response, nil
+		}
+
+		response.Bounds = &GeoBounds{
+			TopLeft:     parseGeoPoint(container.Path("bounds.top_left")),
+			BottomRight: parseGeoPoint(container.Path("bounds.bottom_right")),
+		}
+
+		return response, nil
+	case "stats", "extended_stats":
+		response.Stats = parseExtendedStats(container)
+
+		return response, nil
+	case "geo_centroid":
+		if container.Exists("location") {
+			centroid := parseGeoPoint(container.Path("location"))
+
+			response.Centroid = &centroid
+		}
+
+		return response, nil
+	case "filter", "missing", "nested", "reverse_nested":
+		docCount, _ := container.Path("doc_count").Data().(float64)
+		items, err := a.parseSubAggregations(container)
+
+		if err != nil {
+			return nil, err
+		}
+
+		count := int(docCount)
+
+		response.DocCount = &count
+		response.Items = items
+
+		return response, nil
+	}
+
+	docCountErrorUpperBound, _ := container.Path("doc_count_error_upper_bound").Data().(float64)
+	sumOtherDocCount, _ := container.Path("sum_other_doc_count").Data().(float64)
+
+	response.DocCountErrorUpperBound = int(docCountErrorUpperBound)
+	response.SumOtherDocCount = int(sumOtherDocCount)
+	response.Buckets = []*AggregationBucket{}
+
+	if a.aggType == "filters" {
+		for _, filter := range a.filters {
+			bucket, err := a.parseBucket(filter.Name, container.Search("buckets", filter.Name))
+
+			if err != nil {
+				return nil, err
+			}
+
+			response.Buckets = append(response.Buckets, bucket)
+		}
+
+		return response, nil
+	}
+
+	buckets, err := container.Path("buckets").Children()
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, container := range buckets {
+		bucket, err := a.parseBucket(container.Path("key").Data(), container)
+
+		if err != nil {
+			return nil, err
+		}
+
+		response.Buckets = append(response.Buckets, bucket)
+	}
+
+	return response, nil
+}
+
+func (a *Aggregation) parseBucket(key interface{}, container *gabs.Container) (*AggregationBucket, error) {
+	items, err := a.parseSubAggregations(container)
+
+	if err != nil {
+		return nil, err
+	}
+
+	docCount, _ := container.Path("doc_count").Data().(float64)
+	keyAsString, _ := container.Path("key_as_string").Data().(string)
+
+	bucket := &AggregationBucket{
+		Key:         key,
+		KeyAsString: keyAsString,
+		DocCount:    int(docCount),
+		Items:       items,
+	}
+
+	if from, valid := container.Path("from").Data().(float64); valid {
+		bucket.From = &from
+	}
+
+	if to, valid := container.Path("to").Data().(float64); valid {
+		bucket.To = &to
+	}
+
+	return bucket, nil
+}
+
+func (a *Aggregation) parseSubAggregations(container *gabs.Container) (AggregationResponses, error) {
+	items := AggregationResponses{}
+
+	for _, subAggregation := range a.subAggregations {
+		if !container.Exists(subAggregation.name) {
+			continue
+		}
+
+		item, err := subAggregation.parse(container.Search(subAggregation.name))
+
+		if err != nil {
+			return nil, err
+		}
+
+		items[subAggregation.name] = item
+	}
+
+	return items, nil
+}
+
+func parseExtendedStats(container *gabs.Container) *ExtendedStatsResponse {
+	count, _ := container.Path("count").Data().(float64)
+
+	stats := &ExtendedStatsResponse{
+		Count:        int64(count),
+		Min:          parseFloat(container, "min"),
+		Max:          parseFloat(container, "max"),
+		Avg:          parseFloat(container, "avg"),
+		Sum:          parseFloat(container, "sum"),
+		SumOfSquares: parseFloat(container, "sum_of_squares"),
+		Variance:     parseFloat(container, "variance"),
+		StdDeviation: parseFloat(container, "std_deviation"),
+	}
+
+	if container.Exists("std_deviation_bounds") {
+		stats.StdDeviationBounds = &StdDeviationBounds{
+			Upper: parseFloat(container, "std_deviation_bounds.upper"),
+			Lower: parseFloat(container, "std_deviation_bounds.lower"),
+		}
+	}
+
+	return stats
+}
+
+func parseFloat(container *gabs.Container, path string) *float64 {
+	value, valid := container.Path(path).Data().(float64)
+
+	if !valid {
+		return nil
+	}
+
+	return &value
+}
+
+func parseGeoPoint(container *gabs.Container) GeoPoint {
+	lat, _ := container.Path("lat").Data().(float64)
+	lon, _ := container.Path("lon").Data().(float64)
+
+	return GeoPoint{Lat: lat, Lon: lon}
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch number := value.(type) {
+	case float64:
+		return number, true
+	case float32:
+		return float64(number), true
+	case int:
+		return float64(number), true
+	case int32:
+		return float64(number), true
+	case int64:
+		return float64(number), true
+	}
+
+	return 0, false
+}
+
+// nestedAggregations implements elastic.Aggregation for attaching sub-aggregations
+// to any of the aggregations provided by the elastic client
+type nestedAggregations struct {
+	aggregation     elastic.Aggregation
+	subAggregations map[string]elastic.Aggregation
+}
+
+// Source returns the JSON-serializable aggregation
+func (na *nestedAggregations) Source() (interface{}, error) {
+	source, err := na.aggregation.Source()
+
+	if err != nil {
+		return nil, err
+	}
+
+	aggregation, valid := source.(map[string]interface{})
+
+	if !valid {
+		return nil, errors.New("Invalid aggregation source")
+	}
+
+	subAggregations := map[string]interface{}{}
+
+	for name, subAggregation := range na.subAggregations {
+		subSource, err := subAggregation.Source()
+
+		if err != nil {
+			return nil, err
+		}
+
+		subAggregations[name] = subSource
+	}
+
+	aggregation["aggregations"] = subAggregations
+
+	return aggregation, nil
+}
@@ -0,0 +1,299 @@
+package golastic
+
+import (
+	"encoding/json"
+	"testing"
+
+	elastic "github.com/alejandro-carstens/elasticfork"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregationValidation(t *testing.T) {
+	valid := []*Aggregation{
+		TermsAgg("genre").Size(5).MinDocCount(1).OrderBy("_count", false).SubAggregation("avg_rating", AvgAgg("rating")),
+		DateHistogramAgg("release_date", "month").Format("yyyy-MM").TimeZone("-05:00").ExtendedBounds("2019-01", "2019-12"),
+		HistogramAgg("price", 10).ExtendedBounds(0, 100),
+		RangeAgg("price").Range("cheap", nil, 10).Range("expensive", 10, nil),
+		FiltersAgg().Filter("classics", func(builder *Builder) { builder.Where("year", "<", 1980) }),
+		PercentilesAgg("rating", 50, 95, 99),
+		TopHitsAgg(3).OrderBy("rating", false).Select("title"),
+		GeoHashGridAgg("location", 5),
+		GeoDistanceAgg("location", GeoPoint{Lat: 40.7, Lon: -74}, "km").Range("near", nil, 10),
+	}
+
+	for _, aggregation := range valid {
+		aggregation.name = "aggregation"
+
+		if got := aggregation.validate(); got != nil {
+			t.Error("Expected no errors but got ", got)
+		}
+	}
+
+	invalid := []*Aggregation{
+		TermsAgg(""),
+		TermsAgg("genre").Size(0),
+		TermsAgg("genre").Format("yyyy"),
+		AvgAgg("rating").SubAggregation("max_rating", MaxAgg("rating")),
+		TermsAgg("genre").SubAggregation("", AvgAgg("rating")),
+		TermsAgg("genre").SubAggregation("rating", AvgAgg("rating")).SubAggregation("rating", MaxAgg("rating")),
+		HistogramAgg("price", 0),
+		HistogramAgg("price", 10).ExtendedBounds("low", "high"),
+		DateHistogramAgg("release_date", ""),
+		RangeAgg("price"),
+		RangeAgg("price").Range("all", nil, nil),
+		FiltersAgg(),
+		FiltersAgg().Filter("classics", func(builder *Builder) { builder.Where("year", "~", 1980) }),
+		PercentilesAgg("rating", 101),
+		PercentileRanksAgg("rating"),
+		GeoHashGridAgg("location", 13),
+		GeoDistanceAgg("location", GeoPoint{Lat: 100}, "km").Range("near", nil, 10),
+		GeoDistanceAgg("location", GeoPoint{}, "leagues").Range("near", nil, 10),
+	}
+
+	for _, aggregation := range invalid {
+		aggregation.name = "aggregation"
+
+		if got := aggregation.validate(); got == nil {
+			t.Error("Expected errors but got ", got)
+		}
+	}
+
+	builder := new(Builder)
+	builder.Aggregation("genres", TermsAgg("genre")).Aggregation("genres", TermsAgg("director"))
+
+	_, err := builder.searchSource()
+
+	assert.NotNil(t, err)
+
+	builder = new(Builder)
+	builder.Stats("price")
+	builder.Aggregation("price", AvgAgg("price"))
+
+	_, err = builder.searchSource()
+
+	assert.NotNil(t, err)
+
+	builder = new(Builder)
+	builder.GroupBy("genre", "director")
+	builder.Aggregation("genre", CardinalityAgg("genre"))
+
+	_, err = builder.searchSource()
+
+	assert.NotNil(t, err)
+
+	builder = new(Builder)
+	builder.GroupBy("genre").Stats("genre")
+
+	_, err = builder.searchSource()
+
+	assert.NotNil(t, err)
+
+	builder = new(Builder)
+	builder.GroupBy("genre", "director").Stats("rating")
+	builder.Aggregation("director", CardinalityAgg("director"))
+
+	_, err = builder.searchSource()
+
+	assert.Nil(t, err)
+}
+
+func TestAggregationSource(t *testing.T) {
+	builder := new(Builder)
+	builder.Aggregation(
+		"genres",
+		TermsAgg("genre").Size(5).SubAggregation(
+			"per_month",
+			DateHistogramAgg("release_date", "month").SubAggregation("avg_rating", AvgAgg("rating")),
+		),
+	)
+
+	source, err := builder.searchSource()
+
+	assert.Nil(t, err)
+
+	data, err := source.Source()
+
+	assert.Nil(t, err)
+
+	container, err := toGabsContainer(data)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "genre", container.Path("aggregations.genres.terms.field").Data())
+	assert.Equal(t, float64(5), container.Path("aggregations.genres.terms.size").Data())
+	assert.Equal(t, "month", container.Path("aggregations.genres.aggregations.per_month.date_histogram.interval").Data())
+	assert.Equal(
+		t,
+		"rating",
+		container.Path("aggregations.genres.aggregations.per_month.aggregations.avg_rating.avg.field").Data(),
+	)
+}
+
+func TestAggregationQuerySource(t *testing.T) {
+	builder := new(Builder)
+	builder.Where("status", "=", "published")
+	builder.Score().MinScore(1)
+	builder.Aggregation("genres", TermsAgg("genre"))
+	builder.Facet("brand", "acme")
+
+	source, err := builder.aggregationSource()
+
+	assert.Nil(t, err)
+
+	data, err := source.Source()
+
+	assert.Nil(t, err)
+
+	container, err := toGabsContainer(data)
+
+	assert.Nil(t, err)
+	assert.True(t, container.Exists("query", "bool"))
+	assert.False(t, container.Exists("query", "function_score"))
+	assert.False(t, container.Exists("aggregations"))
+	assert.False(t, container.Exists("post_filter"))
+	assert.Equal(t, float64(0), container.Path("size").Data())
+}
+
+func TestAggregationParsing(t *testing.T) {
+	builder := new(Builder)
+	builder.Aggregation(
+		"genres",
+		TermsAgg("genre").
+			SubAggregation("avg_rating", AvgAgg("rating")).
+			SubAggregation("percentiles", PercentilesAgg("rating", 50)).
+			SubAggregation("top", TopHitsAgg(1)),
+	).Aggregation(
+		"eras",
+		FiltersAgg().
+			Filter("classics", func(builder *Builder) { builder.Where("year", "<", 1980) }).
+			Filter("modern", func(builder *Builder) { builder.Where("year", ">=", 1980) }),
+	).Aggregation("area", GeoBoundsAgg("location"))
+
+	raw := `{
+		"genres": {
+			"doc_count_error_upper_bound": 0,
+			"sum_other_doc_count": 2,
+			"buckets": [{
+				"key": "drama",
+				"doc_count": 3,
+				"avg_rating": {"value": 8.5},
+				"percentiles": {"values": {"50.0": 8.0}},
+				"top": {"hits": {"hits": [{"_source": {"title": "Avatar"}}]}}
+			}]
+		},
+		"eras": {"buckets": {"modern": {"doc_count": 4}, "classics": {"doc_count": 1}}},
+		"area": {"bounds": {"top_left": {"lat": 41, "lon": -75}, "bottom_right": {"lat": 40, "lon": -73}}}
+	}`
+
+	aggregations := elastic.Aggregations{}
+
+	assert.Nil(t, json.Unmarshal([]byte(raw), &aggregations))
+
+	response, err := builder.processAggregations(aggregations)
+
+	assert.Nil(t, err)
+
+	genre := response["genres"].Buckets[0]
+
+	assert.Equal(t, 2, response["genres"].SumOtherDocCount)
+	assert.Equal(t, "drama", genre.Key)
+	assert.Equal(t, 3, genre.DocCount)
+	assert.Equal(t, 8.5, *genre.Items["avg_rating"].Value)
+	assert.Equal(t, float64(8), *genre.Items["percentiles"].Values["50.0"])
+
+	movies := []map[string]interface{}{}
+
+	assert.Nil(t, genre.Items["top"].Decode(&movies))
+	assert.Equal(t, "Avatar", movies[0]["title"])
+	assert.Equal(t, "classics", response["eras"].Buckets[0].Key)
+	assert.Equal(t, 1, response["eras"].Buckets[0].DocCount)
+	assert.Equal(t, "modern", response["eras"].Buckets[1].Key)
+	assert.Equal(t, float64(41), response["area"].Bounds.TopLeft.Lat)
+	assert.Equal(t, float64(-73), response["area"].Bounds.BottomRight.Lon)
+}
+
+func TestStatsAggregations(t *testing.T) {
+	builder := new(Builder)
+	builder.Stats("rating", "views")
+	builder.Aggregation("genres", TermsAgg("genre").SubAggregation("rating", StatsAgg("rating")))
+
+	source, err := builder.searchSource()
+
+	assert.Nil(t, err)
+
+	data, err := source.Source()
+
+	assert.Nil(t, err)
+
+	container, err := toGabsContainer(data)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "rating", container.Path("aggregations.rating.extended_stats.field").Data())
+	assert.Equal(t, "views", container.Path("aggregations.views.extended_stats.field").Data())
+
+	raw := `{
+		"rating": {
+			"count": 2, "min": 7, "max": 9, "avg": 8, "sum": 16, "sum_of_squares": 130,
+			"variance": 1, "std_deviation": 1, "std_deviation_bounds": {"upper": 10, "lower": 6}
+		},
+		"views": {"count": 0, "min": null, "max": null, "avg": null, "sum": 0},
+		"genres": {"buckets": [{"key": "drama", "doc_count": 2, "rating": {"count": 2, "min": 7, "max": 9, "avg": 8, "sum": 16}}]}
+	}`
+
+	aggregations := elastic.Aggregations{}
+
+	assert.Nil(t, json.Unmarshal([]byte(raw), &aggregations))
+
+	response, err := builder.processAggregations(aggregations)
+
+	assert.Nil(t, err)
+
+	rating := response["rating"].Stats
+
+	assert.Equal(t, int64(2), rating.Count)
+	assert.Equal(t, float64(7), *rating.Min)
+	assert.Equal(t, float64(1), *rating.StdDeviation)
+	assert.Equal(t, float64(10), *rating.StdDeviationBounds.Upper)
+	assert.Equal(t, int64(0), response["views"].Stats.Count)
+	assert.Nil(t, response["views"].Stats.Avg)
+	assert.Nil(t, response["views"].Stats.StdDeviationBounds)
+	assert.Equal(t, float64(8), *response["genres"].Buckets[0].Items["rating"].Stats.Avg)
+}
+
+func TestNestedAggregations(t *testing.T) {
+	builder := new(Builder)
+	builder.Aggregation("cast", NestedAgg("cast").SubAggregation(
+		"directors",
+		TermsAgg("cast.director").SubAggregation("movies", ReverseNestedAgg("")),
+	))
+
+	source, err := builder.searchSource()
+
+	assert.Nil(t, err)
+
+	data, err := source.Source()
+
+	assert.Nil(t, err)
+
+	container, err := toGabsContainer(data)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "cast", container.Path("aggregations.cast.nested.path").Data())
+	assert.True(t, container.Exists("aggregations", "cast", "aggregations", "directors", "aggregations", "movies", "reverse_nested"))
+
+	raw := `{"cast": {"doc_count": 8, "directors": {"buckets": [{"key": "James Cameron", "doc_count": 4, "movies": {"doc_count": 3}}]}}}`
+
+	aggregations := elastic.Aggregations{}
+
+	assert.Nil(t, json.Unmarshal([]byte(raw), &aggregations))
+
+	response, err := builder.processAggregations(aggregations)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 8, *response["cast"].DocCount)
+	assert.Equal(t, 3, *response["cast"].Items["directors"].Buckets[0].Items["movies"].DocCount)
+
+	nested := NestedAgg("")
+	nested.name = "cast"
+
+	assert.NotNil(t, nested.validate())
+}
@@ -13,15 +13,55 @@ import (
 // and executing elasticsearch queries
 type Builder struct {
 	queryBuilder
-	index    string
-	client   *elastic.Client
-	context  context.Context
-	scroller *elastic.ScrollService
+	index         string
+	client        *elastic.Client
+	context       context.Context
+	scroller      *elastic.ScrollService
+	score         *Score
+	highlight     *Highlight
+	collapse      *Collapse
+	suggestions   []*Suggestion
+	facets        []*Facet
+	aggregations  []*Aggregation
+	groupByFields map[string]*GroupByField
+	statsFields   map[string]*StatsField
+	join          *joinRelation
+	relations     []*Relation
+	indices       []string
+	indexBoosts   map[string]float64
+	indicesOpts   *indicesOptions
+	routing       string
+	preference    string
+	routingFunc   RoutingFunc
 }
 
 // Find retrieves an instance of a model for the specified Id from the corresponding elasticsearch index
 func (b *Builder) Find(id string, item interface{}) error {
-	response, err := b.client.Get().Index(b.index).Id(id).Do(b.context)
+	routing, err := b.joinRouting(b.routing)
+
+	if err != nil {
+		return err
+	}
+
+	service := b.client.Get().Index(b.index).Id(id)
+
+	if len(routing) > 0 {
+		service = service.Routing(routing)
+	}
+
+	if len(b.preference) > 0 {
+		service = service.Preference(b.preference)
+	}
+
+	if b.sourceFilter != nil {
+		service = service.FetchSourceContext(b.fetchSourceContext())
+	}
+
+	if len(b.storedFields) > 0 {
+		service = service.StoredFields(b.storedFields...)
+	}
+
+	response, err := service.Do(b.context)
 
 	if err != nil {
 		return err
@@ -40,13 +80,238 @@ func (b *Builder) Find(id string, item interface{}) error {
 	return json.Unmarshal(data, item)
 }
 
+// FindMany retrieves the documents with the given ids in a single request, the found documents are decoded
+// into items in the order they were requested while the missing and failed ids are reported on the response
+func (b *Builder) FindMany(ids []string, items interface{}) (*FindManyResponse, error) {
+	documents := []*DocumentId{}
+
+	for _, id := range ids {
+		documents = append(documents, &DocumentId{Id: id})
+	}
+
+	return b.FindDocuments(documents, items)
+}
+
+// FindDocuments is the counterpart of FindMany for retrieving documents that live
+// on different indices or that require a specific routing
+func (b *Builder) FindDocuments(documents []*DocumentId, items interface{}) (*FindManyResponse, error) {
+	if len(documents) == 0 {
+		return nil, errors.New("Please specify at least a document id")
+	}
+
+	routing, err := b.joinRouting(b.routing)
+
+	if err != nil {
+		return nil, err
+	}
+
+	service := b.client.Mget()
+
+	if len(b.preference) > 0 {
+		service = service.Preference(b.preference)
+	}
+
+	for _, document := range documents {
+		if err := document.validate(); err != nil {
+			return nil, err
+		}
+
+		item := elastic.NewMultiGetItem().Index(b.index).Id(document.Id)
+
+		if len(document.Index) > 0 {
+			item = item.Index(document.Index)
+		}
+
+		if len(document.Routing) > 0 {
+			item = item.Routing(document.Routing)
+		} else if len(routing) > 0 {
+			item = item.Routing(routing)
+		}
+
+		if b.sourceFilter != nil {
+			item = item.FetchSource(b.fetchSourceContext())
+		}
+
+		if len(b.storedFields) > 0 {
+			item = item.StoredFields(b.storedFields...)
+		}
+
+		service = service.Add(item)
+	}
+
+	response, err := service.Do(b.context)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(response.Docs) != len(documents) {
+		return nil, errors.New("The number of documents returned does not match the number of ids.")
+	}
+
+	findManyResponse := &FindManyResponse{
+		Found:   []*DocumentId{},
+		Missing: []*DocumentId{},
+		Errors:  []*DocumentError{},
+	}
+
+	sources := []json.RawMessage{}
+
+	for i, doc := range response.Docs {
+		if doc.Error != nil {
+			findManyResponse.Errors = append(findManyResponse.Errors, &DocumentError{
+				Document: documents[i],
+				Type:     doc.Error.Type,
+				Reason:   doc.Error.Reason,
+			})
+
+			continue
+		}
+
+		if !doc.Found {
+			findManyResponse.Missing = append(findManyResponse.Missing, documents[i])
+			continue
+		}
+
+		findManyResponse.Found = append(findManyResponse.Found, documents[i])
+
+		if len(doc.Source) == 0 {
+			sources = append(sources, json.RawMessage("null"))
+			continue
+		}
+
+		sources = append(sources, doc.Source)
+	}
+
+	results, err := json.Marshal(sources)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return findManyResponse, json.Unmarshal(results, items)
+}
+
+// Score returns the function_score sub-builder used to tune the relevance of the
+// hits, the builder's query gets wrapped in a function_score query when searching
+func (b *Builder) Score() *Score {
+	if b.score == nil {
+		b.score = &Score{}
+	}
+
+	return b.score
+}
+
+// Highlight returns the highlighting sub-builder for the given fields, the
+// highlighted fragments of each hit are returned by Search
+func (b *Builder) Highlight(fields ...string) *Highlight {
+	if b.highlight == nil {
+		b.highlight = &Highlight{}
+	}
+
+	b.highlight.fields = append(b.highlight.fields, fields...)
+
+	return b.highlight
+}
+
+// CollapseBy collapses the search results by the given field returning only the top hit for each
+// distinct value, use the returned sub-builder for retrieving the inner hits of each group
+func (b *Builder) CollapseBy(field string) *Collapse {
+	b.collapse = &Collapse{field: field}
+
+	return b.collapse
+}
+
+// SuggestTerm adds a term suggester which suggests terms based on edit distance
+func (b *Builder) SuggestTerm(name string, field string, text string) *Suggestion {
+	return b.suggest("term", name, field, text)
+}
+
+// SuggestPhrase adds a phrase suggester for "did you mean" functionality
+func (b *Builder) SuggestPhrase(name string, field string, text string) *Suggestion {
+	return b.suggest("phrase", name, field, text)
+}
+
+// SuggestCompletion adds a completion suggester for the given prefix
+// on a field mapped with the completion type
+func (b *Builder) SuggestCompletion(name string, field string, prefix string) *Suggestion {
+	return b.suggest("completion", name, field, prefix)
+}
+
+// IndicesOptions sets whether unavailable indices are ignored and whether
+// wildcard patterns that resolve to no indices are allowed when searching
+func (b *Builder) IndicesOptions(ignoreUnavailable bool, allowNoIndices bool) *Builder {
+	b.indicesOpts = &indicesOptions{
+		IgnoreUnavailable: ignoreUnavailable,
+		AllowNoIndices:    allowNoIndices,
+	}
+
+	return b
+}
+
+// IndexBoost boosts the score of the hits coming from the given index or index pattern
+func (b *Builder) IndexBoost(index string, boost float64) *Builder {
+	if b.indexBoosts == nil {
+		b.indexBoosts = map[string]float64{}
+	}
+
+	b.indexBoosts[index] = boost
+
+	return b
+}
+
+// Routing routes the writes and restricts the reads to the shards of the given routing value
+func (b *Builder) Routing(routing string) *Builder {
+	b.routing = routing
+
+	return b
+}
+
+// Preference sets the shard copies the reads are executed on, i.e. "_local" or a custom string
+func (b *Builder) Preference(preference string) *Builder {
+	b.preference = preference
+
+	return b
+}
+
+// RouteBy sets a function extracting the routing of each of the documents written by Insert,
+// InsertWithOverwrittenId and Update, it takes precedence over Routing. The routing of a child
+// document needs to match the parent of its JoinRelation, otherwise the write is rejected
+func (b *Builder) RouteBy(routingFunc RoutingFunc) *Builder {
+	b.routingFunc = routingFunc
+
+	return b
+}
+
+// JoinRelation sets the join field relation of the documents written by Insert and InsertWithOverwrittenId.
+// When a parent id is specified it is also used as the mandatory routing of Insert, Update and Delete.
+// The builder holds a single relation, so the children of different parents are written through different
+// builders, while grandchildren, which are routed by their root document, need to set the join field themselves
+func (b *Builder) JoinRelation(field string, name string, parent string) *Builder {
+	b.join = &joinRelation{Field: field, Name: name, Parent: parent}
+
+	return b
+}
+
 // InsertWithOverwrittenId allows to overwrite the of the given document on creation
 func (b *Builder) InsertWithOverwrittenId(items map[string]interface{}) (*gabs.Container, error) {
 	bulkClient := b.client.Bulk()
 
 	for id, item := range items {
+		doc, err := b.joinDocument(item)
+
+		if err != nil {
+			return nil, err
+		}
+
+		routing, err := b.documentRouting(item)
+
+		if err != nil {
+			return nil, err
+		}
+
 		bulkClient = bulkClient.Add(
-			elastic.NewBulkIndexRequest().Index(b.index).Id(id).OpType("create").Doc(item),
+			elastic.NewBulkIndexRequest().Index(b.index).Id(id).OpType("create").Routing(routing).Doc(doc),
 		)
 	}
 
@@ -75,8 +340,20 @@ func (b *Builder) Insert(items ...interface{}) (*gabs.Container, error) {
 			return nil, errors.New("id not specified in document.")
 		}
 
+		joinDoc, err := b.joinDocument(item)
+
+		if err != nil {
+			return nil, err
+		}
+
+		routing, err := b.documentRouting(item)
+
+		if err != nil {
+			return nil, err
+		}
+
 		bulkClient = bulkClient.Add(
-			elastic.NewBulkIndexRequest().Index(b.index).Id(id).OpType("create").Doc(item),
+			elastic.NewBulkIndexRequest().Index(b.index).Id(id).OpType("create").Routing(routing).Doc(joinDoc),
 		)
 	}
 
@@ -85,11 +362,17 @@ func (b *Builder) Insert(items ...interface{}) (*gabs.Container, error) {
 
 // Delete deletes one or multiple documents by id from the corresponding elasticsearch index
 func (b *Builder) Delete(ids ...string) (*gabs.Container, error) {
+	routing, err := b.joinRouting(b.routing)
+
+	if err != nil {
+		return nil, err
+	}
+
 	batchClient := b.client.Bulk()
 
 	for _, id := range ids {
 		batchClient = batchClient.Add(
-			elastic.NewBulkDeleteRequest().Index(b.index).Id(id),
+			elastic.NewBulkDeleteRequest().Index(b.index).Id(id).Routing(routing),
 		)
 	}
 
@@ -113,8 +396,14 @@ func (b *Builder) Update(items ...interface{}) (*gabs.Container, error) {
 			return nil, errors.New("id not specified in document.")
 		}
 
+		routing, err := b.documentRouting(item)
+
+		if err != nil {
+			return nil, err
+		}
+
 		batchClient = batchClient.Add(
-			elastic.NewBulkUpdateRequest().Index(b.index).Id(id).Doc(item),
+			elastic.NewBulkUpdateRequest().Index(b.index).Id(id).Routing(routing).Doc(item),
 		)
 	}
 
@@ -135,11 +424,7 @@ func (b *Builder) Aggregate() (map[string]*AggregationResponse, error) {
 		return nil, err
 	}
 
-	if response.Aggregations == nil {
-		return nil, errors.New("No aggregations returned")
-	}
-
-	return b.processAggregations(response.Aggregations)
+	return b.decodeAggregations(response)
 }
 
 // AggregateRaw returns raw aggregation results
@@ -177,15 +462,87 @@ func (b *Builder) Get(items interface{}) error {
 		return err
 	}
 
-	sources := b.processGetResults(response.Hits.Hits)
+	return b.decodeHits(response, items)
+}
 
-	results, err := toJson(sources)
+// Search executes the search query, decodes the results into items
+// and returns the metadata associated to each of the returned hits
+func (b *Builder) Search(items interface{}) (*SearchResponse, error) {
+	searchService, err := b.build()
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return json.Unmarshal([]byte(results), items)
+	response, err := searchService.Do(b.context)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return b.decodeSearchResponse(response, items)
+}
+
+// Pluck executes the search query and retrieves the values of the given field for each of the hits
+func (b *Builder) Pluck(field string) ([]interface{}, error) {
+	source, err := b.searchSource()
+
+	if err != nil {
+		return nil, err
+	}
+
+	source = source.FetchSourceContext(elastic.NewFetchSourceContext(true).Include(field))
+
+	response, err := b.searchService().SearchSource(source).Do(b.context)
+
+	if err != nil {
+		return nil, err
+	}
+
+	values := []interface{}{}
+
+	for _, hit := range response.Hits.Hits {
+		if len(hit.Source) == 0 {
+			values = append(values, nil)
+			continue
+		}
+
+		source, err := gabs.ParseJSON(hit.Source)
+
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, source.Path(field).Data())
+	}
+
+	return values, nil
+}
+
+// Suggest executes the suggesters without running the search query, the suggestions are
+// also returned by Search when the suggesters need to be executed alongside a search
+func (b *Builder) Suggest() (SuggestResponses, error) {
+	if len(b.suggestions) == 0 {
+		return nil, errors.New("Please specify at least a suggester")
+	}
+
+	service := b.searchService().Size(0)
+
+	for _, suggestion := range b.suggestions {
+		if err := suggestion.validate(); err != nil {
+			return nil, err
+		}
+
+		service = service.Suggester(suggestion.suggester())
+	}
+
+	response, err := service.Do(b.context)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return processSuggestions(response.Suggest), nil
 }
 
 // Execute executes an update by query
@@ -224,8 +581,7 @@ func (b *Builder) ExecuteAsync(scrollSize int, params map[string]interface{}) (*
 
 // Destroy executes a delete by query
 func (b *Builder) Destroy() (*gabs.Container, error) {
-	response, err := b.client.
-		DeleteByQuery(b.index).
+	response, err := b.deleteByQuery().
 		Refresh("true").
 		ProceedOnVersionConflict().
 		Query(b.query()).
@@ -240,8 +596,7 @@ func (b *Builder) Destroy() (*gabs.Container, error) {
 
 // DestroyAsync executes a delete by query asynchronously
 func (b *Builder) DestroyAsync() (*gabs.Container, error) {
-	response, err := b.client.
-		DeleteByQuery(b.index).
+	response, err := b.deleteByQuery().
 		ProceedOnVersionConflict().
 		Query(b.query()).
 		DoAsync(b.context)
@@ -264,7 +619,21 @@ func (b *Builder) Count() (int64, error) {
 		return 0, err
 	}
 
-	return b.client.Count(b.index).Query(b.query()).Pretty(true).Do(b.context)
+	service := b.client.Count(b.searchIndices()...)
+
+	if b.indicesOpts != nil {
+		service = service.IgnoreUnavailable(b.indicesOpts.IgnoreUnavailable).AllowNoIndices(b.indicesOpts.AllowNoIndices)
+	}
+
+	if len(b.routing) > 0 {
+		service = service.Routing(b.routing)
+	}
+
+	if len(b.preference) > 0 {
+		service = service.Preference(b.preference)
+	}
+
+	return service.Query(b.query()).Pretty(true).Do(b.context)
 }
 
 // Cursor paginates based on searching after the last returned sortValues
@@ -304,30 +673,91 @@ func (b *Builder) Cursor(offset int, sortValues []interface{}, items interface{}
 	return sortResponse, json.Unmarshal([]byte(results), items)
 }
 
-// MinMax returns the minimum and maximum values for a given field on an index
+// MinMax returns the minimum and maximum values for a given field for the documents matching the query
 func (b *Builder) MinMax(field string, isDateField bool) (*MinMaxResponse, error) {
-	rawQuery := `{
-		"aggs": {
-		  "min": {
-			"min": {
-			  "field": "` + field + `"
-			}
-		  },
-		  "max": {
-			"max": {
-			  "field": "` + field + `"
-			}
-		  }
+	min := MinAgg(field)
+	min.name = "min"
+
+	max := MaxAgg(field)
+	max.name = "max"
+
+	responses, err := b.aggregateQuery(min, max)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return b.parseMinMaxResponse(responses, isDateField)
+}
+
+// Sum returns the sum of the values of the given field for the documents matching the query
+func (b *Builder) Sum(field string) (*float64, error) {
+	return b.singleValueAggregate(SumAgg(field))
+}
+
+// Avg returns the average of the values of the given field for the documents matching the query,
+// nil is returned when none of the documents has a value for the field
+func (b *Builder) Avg(field string) (*float64, error) {
+	return b.singleValueAggregate(AvgAgg(field))
+}
+
+// Min returns the minimum value of the given field for the documents matching the query,
+// nil is returned when none of the documents has a value for the field
+func (b *Builder) Min(field string) (*float64, error) {
+	return b.singleValueAggregate(MinAgg(field))
+}
+
+// Max returns the maximum value of the given field for the documents matching the query,
+// nil is returned when none of the documents has a value for the field
+func (b *Builder) Max(field string) (*float64, error) {
+	return b.singleValueAggregate(MaxAgg(field))
+}
+
+// CountDistinct returns the approximate number of distinct values of the given field for the
+// documents matching the query, the count is expected to be close to accurate below 3000 values
+func (b *Builder) CountDistinct(field string) (int64, error) {
+	value, err := b.singleValueAggregate(CardinalityAgg(field))
+
+	if err != nil || value == nil {
+		return 0, err
+	}
+
+	return int64(*value), nil
+}
+
+// Distinct returns every distinct value of the given field for the documents matching the query,
+// the values are retrieved in ascending order by paginating through a composite aggregation, numbers as json.Number
+func (b *Builder) Distinct(field string) ([]interface{}, error) {
+	values := []interface{}{}
+
+	err := b.Composite("distinct", DISTINCT_PAGE_SIZE).Terms("value", field).Each(func(buckets []*CompositeBucket) error {
+		for _, bucket := range buckets {
+			values = append(values, bucket.Key["value"])
 		}
-	  }`
 
-	result, err := b.client.Search().Index(b.index).Source(rawQuery).Size(0).Do(b.context)
+		return nil
+	})
 
 	if err != nil {
 		return nil, err
 	}
 
-	return b.parseMinMaxResponse(result.Aggregations, isDateField)
+	return values, nil
+}
+
+// Exists checks whether at least one document matches the query
+func (b *Builder) Exists() (bool, error) {
+	if err := b.validateMustClauses(); err != nil {
+		return false, err
+	}
+
+	response, err := b.searchService().Query(b.query()).Size(0).TerminateAfter(1).Do(b.context)
+
+	if err != nil {
+		return false, err
+	}
+
+	return response.TotalHits() > 0, nil
 }
 
 // GetTask retrieves a task given a taskId
@@ -354,19 +784,18 @@ func (b *Builder) CancelTask(taskId string) (*gabs.Container, error) {
 
 // InitScroller initializes the scroller
 func (b *Builder) InitScroller(size int, scroll string) *Builder {
-	b.scroller = b.client.Scroll(b.index).Query(b.query()).Size(size).Scroll(scroll)
+	b.scroller = b.scrollService().SearchSource(b.scrollSource()).Size(size).Scroll(scroll)
 
 	return b
 }
 
 // InitSlicedScroller boots a sliced scroller
 func (b *Builder) InitSlicedScroller(id, max, size int, scroll string) *Builder {
-	query := b.query()
 	sliceQuery := elastic.NewSliceQuery().Id(id).Max(max)
 
-	b.scroller = b.client.Scroll(b.index).
+	b.scroller = b.scrollService().
+		SearchSource(b.scrollSource()).
 		Slice(sliceQuery).
-		Query(query).
 		Size(size).
 		Scroll(scroll)
 
@@ -493,15 +922,151 @@ func (b *Builder) processChunks(channels chan map[int][]*json.RawMessage, hits [
 	channels <- result
 }
 
-func (b *Builder) processBulkRequest(batchClient *elastic.BulkService, num int) (*gabs.Container, error) {
-	if batchClient.NumberOfActions() != num {
-		return nil, errors.New("The number of actions does not match the number of arguments.")
+func (b *Builder) decodeHits(response *elastic.SearchResult, items interface{}) error {
+	sources := b.processGetResults(response.Hits.Hits)
+
+	if len(b.relations) > 0 {
+		return b.loadRelations(sources, items)
 	}
 
-	response, err := batchClient.Do(b.context)
+	results, err := toJson(sources)
 
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	return json.Unmarshal([]byte(results), items)
+}
+
+func (b *Builder) decodeSearchResponse(response *elastic.SearchResult, items interface{}) (*SearchResponse, error) {
+	sources := b.processGetResults(response.Hits.Hits)
+
+	results, err := toJson(sources)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(results), items); err != nil {
+		return nil, err
+	}
+
+	searchResponse := b.processSearchResponse(response)
+
+	if len(b.facets) == 0 {
+		return searchResponse, nil
+	}
+
+	facets, err := b.processFacets(response.Aggregations)
+
+	if err != nil {
+		return nil, err
+	}
+
+	searchResponse.Facets = facets
+
+	return searchResponse, nil
+}
+
+func (b *Builder) decodeAggregations(response *elastic.SearchResult) (map[string]*AggregationResponse, error) {
+	if response.Aggregations == nil {
+		return nil, errors.New("No aggregations returned")
+	}
+
+	return b.processAggregations(response.Aggregations)
+}
+
+func (b *Builder) processSearchResponse(response *elastic.SearchResult) *SearchResponse {
+	searchResponse := &SearchResponse{
+		TotalHits: response.TotalHits(),
+		Hits:      []*SearchHit{},
+	}
+
+	if len(response.Suggest) > 0 {
+		searchResponse.Suggestions = processSuggestions(response.Suggest)
+	}
+
+	if response.Hits == nil {
+		return searchResponse
+	}
+
+	searchResponse.MaxScore = response.Hits.MaxScore
+
+	for _, hit := range response.Hits.Hits {
+		searchResponse.Hits = append(searchResponse.Hits, b.processSearchHit(hit))
+	}
+
+	return searchResponse
+}
+
+func (b *Builder) processSearchHit(hit *elastic.SearchHit) *SearchHit {
+	searchHit := newSearchHit(hit)
+
+	if position := b.geoDistanceSortPosition(); b.geoDistanceSort != nil && position < len(hit.Sort) {
+		if distance, valid := hit.Sort[position].(float64); valid {
+			searchHit.Distance = &distance
+		}
+	}
+
+	return searchHit
+}
+
+func newSearchHit(hit *elastic.SearchHit) *SearchHit {
+	searchHit := &SearchHit{
+		Id:             hit.Id,
+		Index:          hit.Index,
+		Score:          hit.Score,
+		Sort:           hit.Sort,
+		MatchedQueries: hit.MatchedQueries,
+		Highlight:      hit.Highlight,
+		Fields:         hit.Fields,
+	}
+
+	if len(hit.InnerHits) == 0 {
+		return searchHit
+	}
+
+	searchHit.InnerHits = map[string]*InnerHitsResponse{}
+
+	for name, innerHits := range hit.InnerHits {
+		innerHitsResponse := &InnerHitsResponse{Hits: []*SearchHit{}}
+
+		if innerHits.Hits != nil {
+			if innerHits.Hits.TotalHits != nil {
+				innerHitsResponse.TotalHits = innerHits.Hits.TotalHits.Value
+			}
+
+			for _, innerHit := range innerHits.Hits.Hits {
+				innerHitsResponse.Hits = append(innerHitsResponse.Hits, newSearchHit(innerHit))
+				innerHitsResponse.sources = append(innerHitsResponse.sources, innerHit.Source)
+			}
+		}
+
+		searchHit.InnerHits[name] = innerHitsResponse
+	}
+
+	return searchHit
+}
+
+func (b *Builder) geoDistanceSortPosition() int {
+	position := len(b.sorts)
+
+	if b.nestedSort != nil {
+		position++
+	}
+
+	return position
+}
+
+func (b *Builder) processBulkRequest(batchClient *elastic.BulkService, num int) (*gabs.Container, error) {
+	if batchClient.NumberOfActions() != num {
+		return nil, errors.New("The number of actions does not match the number of arguments.")
+	}
+
+	response, err := batchClient.Do(b.context)
+
+	if err != nil {
+		return nil, err
 	}
 
 	if batchClient.NumberOfActions() != 0 {
@@ -527,63 +1092,48 @@ func (b *Builder) processAggregations(aggregations elastic.Aggregations) (Aggreg
 			return nil, err
 		}
 
-		buckets, err := jsonParsed.Path("buckets").Children()
-
-		if err != nil {
-			return nil, err
+		if b.findFacet(field) != nil {
+			continue
 		}
 
-		items, err := b.processAggregationBuckets(buckets)
+		if aggregation := b.findAggregation(field); aggregation != nil {
+			item, err := aggregation.parse(jsonParsed)
 
-		if err != nil {
-			return nil, err
-		}
+			if err != nil {
+				return nil, err
+			}
 
-		docCountErrorUpperBound, _ := jsonParsed.Path("doc_count_error_upper_bound").Data().(float64)
-		sumOtherDocCount, _ := jsonParsed.Path("sum_other_doc_count").Data().(float64)
+			aggregationResponse[field] = item
 
-		aggregationResponse[field] = &AggregationResponse{
-			DocCountErrorUpperBound: int(docCountErrorUpperBound),
-			SumOtherDocCount:        int(sumOtherDocCount),
-			Buckets:                 items,
+			continue
 		}
-	}
 
-	return aggregationResponse, nil
-}
+		if b.stats != nil && inSlice(field, b.stats.Fields...) {
+			if len(b.statsPath(field)) > 0 {
+				jsonParsed = jsonParsed.Search(field)
+			}
 
-func (b *Builder) processAggregationBuckets(buckets []*gabs.Container) (aggregationBuckets, error) {
-	items := aggregationBuckets{}
+			aggregationResponse[field] = &AggregationResponse{Stats: parseExtendedStats(jsonParsed)}
 
-	for _, bucket := range buckets {
-		subAggregations := AggregationResponses{}
+			continue
+		}
 
-		for _, field := range sliceRemove(0, b.groupBy.Fields) {
-			data, err := json.Marshal(bucket.Path(field).Data())
+		fields := []string{}
 
-			if err != nil {
-				return nil, err
-			}
-
-			var subAggregation *AggregationResponse
+		if b.groupBy != nil {
+			fields = sliceRemove(0, b.groupBy.Fields)
+		}
 
-			if err := json.Unmarshal(data, &subAggregation); err != nil {
-				return nil, err
-			}
+		item, err := b.parseGroupBy(field, b.unwrapNestedGroupBy(field, jsonParsed, ""), fields)
 
-			subAggregations[field] = subAggregation
+		if err != nil {
+			return nil, err
 		}
 
-		docCount, _ := bucket.Path("doc_count").Data().(float64)
-
-		items = append(items, &AggregationBucket{
-			DocCount: int(docCount),
-			Items:    subAggregations,
-			Key:      bucket.Path("key").Data(),
-		})
+		aggregationResponse[field] = item
 	}
 
-	return items, nil
+	return aggregationResponse, nil
 }
 
 func (b *Builder) buildExecuteQuery(params map[string]interface{}) (*elastic.UpdateByQueryService, error) {
@@ -607,17 +1157,141 @@ func (b *Builder) updateByQuery() (*elastic.UpdateByQueryService, error) {
 		return nil, err
 	}
 
-	return b.client.UpdateByQuery(b.index).ProceedOnVersionConflict().Query(b.query()), nil
+	service := b.client.UpdateByQuery(b.searchIndices()...)
+
+	if b.indicesOpts != nil {
+		service = service.IgnoreUnavailable(b.indicesOpts.IgnoreUnavailable).AllowNoIndices(b.indicesOpts.AllowNoIndices)
+	}
+
+	if len(b.routing) > 0 {
+		service = service.Routing(b.routing)
+	}
+
+	if len(b.preference) > 0 {
+		service = service.Preference(b.preference)
+	}
+
+	return service.ProceedOnVersionConflict().Query(b.query()), nil
 }
 
 func (b *Builder) build() (*elastic.SearchService, error) {
-	query := b.client.Search().Index(b.index)
+	source, err := b.searchSource()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return b.searchService().SearchSource(source), nil
+}
+
+func (b *Builder) searchIndices() []string {
+	return append([]string{b.index}, b.indices...)
+}
+
+func (b *Builder) searchService() *elastic.SearchService {
+	service := b.client.Search().Index(b.searchIndices()...)
+
+	if b.indicesOpts != nil {
+		service = service.IgnoreUnavailable(b.indicesOpts.IgnoreUnavailable).AllowNoIndices(b.indicesOpts.AllowNoIndices)
+	}
+
+	if len(b.routing) > 0 {
+		service = service.Routing(b.routing)
+	}
+
+	if len(b.preference) > 0 {
+		service = service.Preference(b.preference)
+	}
+
+	return service
+}
+
+func (b *Builder) scrollService() *elastic.ScrollService {
+	service := b.client.Scroll(b.searchIndices()...)
+
+	if b.indicesOpts != nil {
+		service = service.IgnoreUnavailable(b.indicesOpts.IgnoreUnavailable).AllowNoIndices(b.indicesOpts.AllowNoIndices)
+	}
+
+	if len(b.routing) > 0 {
+		service = service.Routing(b.routing)
+	}
+
+	if len(b.preference) > 0 {
+		service = service.Preference(b.preference)
+	}
+
+	return service
+}
+
+func (b *Builder) deleteByQuery() *elastic.DeleteByQueryService {
+	service := b.client.DeleteByQuery(b.searchIndices()...)
+
+	if b.indicesOpts != nil {
+		service = service.IgnoreUnavailable(b.indicesOpts.IgnoreUnavailable).AllowNoIndices(b.indicesOpts.AllowNoIndices)
+	}
+
+	if len(b.routing) > 0 {
+		service = service.Routing(b.routing)
+	}
+
+	if len(b.preference) > 0 {
+		service = service.Preference(b.preference)
+	}
+
+	return service
+}
+
+func (b *Builder) searchSource() (*elastic.SearchSource, error) {
+	query := elastic.NewSearchSource()
 
 	if err := b.validateMustClauses(); err != nil {
 		return nil, err
 	}
 
-	query = query.Query(b.query())
+	if b.score != nil {
+		if err := b.score.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	query = query.Query(b.searchQuery())
+
+	if b.sourceFilter != nil {
+		query = query.FetchSourceContext(b.fetchSourceContext())
+	}
+
+	if len(b.docValueFields) > 0 {
+		query = query.DocvalueFields(b.docValueFields...)
+	}
+
+	if len(b.storedFields) > 0 {
+		query = query.StoredFields(b.storedFields...)
+	}
+
+	for _, suggestion := range b.suggestions {
+		if err := suggestion.validate(); err != nil {
+			return nil, err
+		}
+
+		query = query.Suggester(suggestion.suggester())
+	}
+
+	if b.collapse != nil {
+		if err := b.collapse.validate(); err != nil {
+			return nil, err
+		}
+
+		query = query.Collapse(b.collapse.collapse())
+	}
+
+	if b.highlight != nil {
+		if err := b.highlight.validate(); err != nil {
+			return nil, err
+		}
+
+		query = query.Highlight(b.highlight.highlight())
+	}
 
 	if b.sorts != nil {
 		for _, sort := range b.sorts {
@@ -643,6 +1317,18 @@ func (b *Builder) build() (*elastic.SearchService, error) {
 		query = query.SortBy(elastic.NewFieldSort(b.nestedSort.Field).Nested(nestedSort).Order(b.nestedSort.Order))
 	}
 
+	if b.geoDistanceSort != nil {
+		geoSort := elastic.NewGeoDistanceSort(b.geoDistanceSort.Field).
+			Point(b.geoDistanceSort.Point.Lat, b.geoDistanceSort.Point.Lon).
+			Asc()
+
+		if len(b.geoDistanceSort.Unit) > 0 {
+			geoSort = geoSort.Unit(b.geoDistanceSort.Unit)
+		}
+
+		query = query.SortBy(geoSort)
+	}
+
 	if b.from != nil {
 		if err := b.validateFrom(); err != nil {
 			return nil, err
@@ -651,17 +1337,199 @@ func (b *Builder) build() (*elastic.SearchService, error) {
 		query = query.From(b.from.From)
 	}
 
+	for index, boost := range b.indexBoosts {
+		query = query.IndexBoost(index, boost)
+	}
+
+	if b.groupBy == nil && len(b.groupByFields) > 0 {
+		return nil, errors.New("Please specify the group by fields through GroupBy before configuring them through GroupByField.")
+	}
+
 	if b.groupBy != nil {
+		for _, options := range b.groupByFields {
+			if err := options.validate(b.groupBy.Fields); err != nil {
+				return nil, err
+			}
+		}
+
 		query = b.processGroupBy(b.groupBy.Fields, query)
 	}
 
+	if b.stats == nil && len(b.statsFields) > 0 {
+		return nil, errors.New("Please specify the stats fields through Stats before configuring them through StatsField.")
+	}
+
 	if b.stats != nil {
+		for _, options := range b.statsFields {
+			if err := options.validate(b.stats.Fields); err != nil {
+				return nil, err
+			}
+		}
+
 		query = b.processStatsAggregations(b.stats.Fields, query)
 	}
 
+	names := []string{}
+
+	for _, name := range b.reservedAggregationNames() {
+		if inSlice(name, names...) {
+			return nil, errors.New("The aggregation name " + name + " is duplicated.")
+		}
+
+		names = append(names, name)
+	}
+
+	for _, aggregation := range b.aggregations {
+		if inSlice(aggregation.name, names...) {
+			return nil, errors.New("The aggregation name " + aggregation.name + " is duplicated.")
+		}
+
+		if err := aggregation.validate(); err != nil {
+			return nil, err
+		}
+
+		names = append(names, aggregation.name)
+		query = query.Aggregation(aggregation.name, aggregation.aggregation())
+	}
+
+	if err := validatePipelines(b.aggregations, nil); err != nil {
+		return nil, err
+	}
+
+	fields := []string{}
+
+	for _, facet := range b.facets {
+		if err := facet.validate(); err != nil {
+			return nil, err
+		}
+
+		if inSlice(facet.field, fields...) {
+			return nil, errors.New("The facet field " + facet.field + " is duplicated.")
+		}
+
+		aggregation := facet.aggregation(b.facets)
+
+		if inSlice(aggregation.name, names...) {
+			return nil, errors.New("The aggregation name " + aggregation.name + " is used by the facet on " + facet.field + ".")
+		}
+
+		if err := aggregation.validate(); err != nil {
+			return nil, err
+		}
+
+		fields = append(fields, facet.field)
+		names = append(names, aggregation.name)
+		query = query.Aggregation(aggregation.name, aggregation.aggregation())
+	}
+
+	if postFilter := b.postFilter(); postFilter != nil {
+		query = query.PostFilter(postFilter)
+	}
+
 	return query, nil
 }
 
+func (b *Builder) joinDocument(item interface{}) (interface{}, error) {
+	if b.join == nil {
+		return item, nil
+	}
+
+	if err := b.join.validate(); err != nil {
+		return nil, err
+	}
+
+	doc, err := toGabsContainer(item)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := doc.SetP(b.join.value(), b.join.Field); err != nil {
+		return nil, err
+	}
+
+	return doc.Data(), nil
+}
+
+func (b *Builder) documentRouting(item interface{}) (string, error) {
+	routing := b.routing
+
+	if b.routingFunc != nil {
+		documentRouting, err := b.routingFunc(item)
+
+		if err != nil {
+			return "", err
+		}
+
+		routing = documentRouting
+	}
+
+	return b.joinRouting(routing)
+}
+
+// joinRouting routes the children to the shard of their parent, which elasticsearch requires
+// for the join to work, hence a routing pointing to any other shard is rejected
+func (b *Builder) joinRouting(routing string) (string, error) {
+	if b.join == nil || len(b.join.Parent) == 0 {
+		return routing, nil
+	}
+
+	if len(routing) == 0 {
+		return b.join.Parent, nil
+	}
+
+	if routing != b.join.Parent {
+		return "", errors.New("The routing " + routing + " does not match the join parent " + b.join.Parent + ".")
+	}
+
+	return routing, nil
+}
+
+func (b *Builder) suggest(suggestType string, name string, field string, text string) *Suggestion {
+	suggestion := &Suggestion{
+		suggestType: suggestType,
+		name:        name,
+		field:       field,
+		text:        text,
+	}
+
+	b.suggestions = append(b.suggestions, suggestion)
+
+	return suggestion
+}
+
+func (b *Builder) scrollSource() *elastic.SearchSource {
+	source := elastic.NewSearchSource().Query(b.searchQuery())
+
+	if b.sourceFilter != nil {
+		source = source.FetchSourceContext(b.fetchSourceContext())
+	}
+
+	if len(b.docValueFields) > 0 {
+		source = source.DocvalueFields(b.docValueFields...)
+	}
+
+	if len(b.storedFields) > 0 {
+		source = source.StoredFields(b.storedFields...)
+	}
+
+	return source
+}
+
+func (b *Builder) fetchSourceContext() *elastic.FetchSourceContext {
+	return elastic.NewFetchSourceContext(true).
+		Include(b.sourceFilter.Includes...).
+		Exclude(b.sourceFilter.Excludes...)
+}
+
+func (b *Builder) searchQuery() elastic.Query {
+	if b.score != nil {
+		return b.score.query(b.query())
+	}
+
+	return b.query()
+}
+
 func (b *Builder) query() *elastic.BoolQuery {
 	wheres := make(chan []elastic.Query)
 	notWheres := make(chan []elastic.Query)
@@ -670,6 +1538,9 @@ func (b *Builder) query() *elastic.BoolQuery {
 	matchPhrases := make(chan []elastic.Query)
 	notMatchPhrases := make(chan []elastic.Query)
 	filters := make(chan []elastic.Query)
+	geoFilters := make(chan []elastic.Query)
+	joinQueries := make(chan []elastic.Query)
+	documentQueries := make(chan []elastic.Query)
 	nestedQueries := make(chan []elastic.Query)
 
 	go func() {
@@ -683,6 +1554,10 @@ func (b *Builder) query() *elastic.BoolQuery {
 		filters <- processFilters(b.filters, b.filterIns)
 	}()
 
+	go func() {
+		geoFilters <- processGeoClauses(&b.geoClauses)
+	}()
+
 	go func() {
 		terms, notTerms := processMatches(b.matches, b.matchIns, b.matchNotIns)
 
@@ -697,6 +1572,14 @@ func (b *Builder) query() *elastic.BoolQuery {
 		notMatchPhrases <- notTerms
 	}()
 
+	go func() {
+		joinQueries <- processJoinClauses(b.hasChilds, b.hasParents, b.parentIds)
+	}()
+
+	go func() {
+		documentQueries <- processDocumentClauses(b.whereIds, b.moreLikeThis, b.whereInLookups)
+	}()
+
 	go b.processNestedQueries(nestedQueries)
 
 	query := elastic.NewBoolQuery().
@@ -705,8 +1588,11 @@ func (b *Builder) query() *elastic.BoolQuery {
 		Must(<-matches...).
 		MustNot(<-notMatches...).
 		Filter(<-filters...).
+		Filter(<-geoFilters...).
 		Must(<-matchPhrases...).
 		MustNot(<-notMatchPhrases...).
+		Must(<-joinQueries...).
+		Must(<-documentQueries...).
 		Must(<-nestedQueries...)
 
 	close(wheres)
@@ -716,6 +1602,9 @@ func (b *Builder) query() *elastic.BoolQuery {
 	close(matchPhrases)
 	close(notMatchPhrases)
 	close(filters)
+	close(geoFilters)
+	close(joinQueries)
+	close(documentQueries)
 	close(nestedQueries)
 
 	return query
@@ -726,6 +1615,7 @@ func (b *Builder) processNestedQueries(nestedQueries chan []elastic.Query) {
 
 	for path, nested := range b.nested {
 		filters := processFilters(nested.filters, nested.filterIns)
+		geoFilters := processGeoClauses(&nested.geoClauses)
 		terms, notTerms := processWheres(nested.wheres, nested.whereIns, nested.whereNotIns)
 		matches, notMatches := processMatches(nested.matches, nil, nil)
 		matchPhrases, notMatchPhrases := processMatchPhrases(nested.matchPhrases, nil, nil)
@@ -734,91 +1624,182 @@ func (b *Builder) processNestedQueries(nestedQueries chan []elastic.Query) {
 			Must(terms...).
 			MustNot(notTerms...).
 			Filter(filters...).
+			Filter(geoFilters...).
 			Must(matches...).
 			MustNot(notMatches...).
 			Must(matchPhrases...).
 			MustNot(notMatchPhrases...)
 
-		queries = append(queries, elastic.NewNestedQuery(path, query))
+		nestedQuery := elastic.NewNestedQuery(path, query)
+
+		if nested.innerHits != nil {
+			nestedQuery = nestedQuery.InnerHit(nested.innerHits.innerHit())
+		}
+
+		queries = append(queries, nestedQuery)
 	}
 
 	nestedQueries <- queries
 }
 
-func (b *Builder) processStatsAggregations(fields []string, query *elastic.SearchService) *elastic.SearchService {
-	name := fields[0]
+// reservedAggregationNames returns the names of the aggregations added through GroupBy and Stats,
+// which cannot be reused by any other aggregation since they share the same response map
+func (b *Builder) reservedAggregationNames() []string {
+	names := []string{}
 
-	aggr := elastic.NewExtendedStatsAggregation().Field(name)
+	if b.groupBy != nil && len(b.groupBy.Fields) > 0 {
+		names = append(names, b.groupBy.Fields[0])
+	}
 
-	for _, field := range sliceRemove(0, fields) {
-		aggr = aggr.SubAggregation(field, elastic.NewExtendedStatsAggregation().Field(field))
+	if b.stats != nil {
+		names = append(names, b.stats.Fields...)
 	}
 
-	return query.Aggregation(name, aggr)
+	return names
 }
 
-func (b *Builder) processGroupBy(fields []string, query *elastic.SearchService) *elastic.SearchService {
+// aggregationNames returns the names of every aggregation added to the builder, including its facets
+func (b *Builder) aggregationNames() []string {
+	names := b.reservedAggregationNames()
+
+	for _, aggregation := range b.aggregations {
+		names = append(names, aggregation.name)
+	}
+
+	for _, facet := range b.facets {
+		names = append(names, facet.name())
+	}
+
+	return names
+}
+
+func (b *Builder) processStatsAggregations(fields []string, query *elastic.SearchSource) *elastic.SearchSource {
+	for _, field := range fields {
+		var aggr elastic.Aggregation = elastic.NewExtendedStatsAggregation().Field(field)
+
+		if path := b.statsPath(field); len(path) > 0 {
+			aggr = elastic.NewNestedAggregation().Path(path).SubAggregation(field, aggr)
+		}
+
+		query = query.Aggregation(field, aggr)
+	}
+
+	return query
+}
+
+func (b *Builder) processGroupBy(fields []string, query *elastic.SearchSource) *elastic.SearchSource {
 	name := fields[0]
 
-	aggr := elastic.NewTermsAggregation().Field(name)
+	aggr := b.groupByAggregation(name)
 
 	for _, field := range sliceRemove(0, fields) {
-		aggr = aggr.SubAggregation(field, elastic.NewTermsAggregation().Field(field))
+		aggr = aggr.SubAggregation(field, b.nestedGroupBy(field, b.groupByAggregation(field), b.groupByPath(name)))
 	}
 
-	return query.Aggregation(name, aggr)
+	return query.Aggregation(name, b.nestedGroupBy(name, aggr, ""))
 }
 
-func (b *Builder) parseMinMaxResponse(aggs elastic.Aggregations, isDateField bool) (*MinMaxResponse, error) {
-	response := &MinMaxResponse{}
+func (b *Builder) singleValueAggregate(aggregation *Aggregation) (*float64, error) {
+	aggregation.name = VALUE
 
-	check := VALUE
+	responses, err := b.aggregateQuery(aggregation)
 
-	if isDateField {
-		check = VALUE_AS_STRING
+	if err != nil {
+		return nil, err
 	}
 
-	min, err := aggs["min"].MarshalJSON()
+	return responses[aggregation.name].Value, nil
+}
 
-	if err != nil {
+// aggregationSource only holds the query so that the scalar helpers, composites and time series neither
+// send the aggregations, facets and post filter of the builder nor take the min score into account
+func (b *Builder) aggregationSource() (*elastic.SearchSource, error) {
+	if err := b.validateMustClauses(); err != nil {
 		return nil, err
 	}
 
-	max, err := aggs["max"].MarshalJSON()
+	return elastic.NewSearchSource().Query(b.query()).Size(0), nil
+}
+
+// aggregateQuery runs the given aggregations over the documents matching the query without retrieving any hits
+func (b *Builder) aggregateQuery(aggregations ...*Aggregation) (AggregationResponses, error) {
+	source, err := b.aggregationSource()
 
 	if err != nil {
 		return nil, err
 	}
 
-	minContainer, err := gabs.ParseJSON(min)
+	for _, aggregation := range aggregations {
+		if err := aggregation.validate(); err != nil {
+			return nil, err
+		}
+
+		source = source.Aggregation(aggregation.name, aggregation.aggregation())
+	}
+
+	response, err := b.searchService().SearchSource(source).Do(b.context)
 
 	if err != nil {
 		return nil, err
 	}
 
-	maxContainer, err := gabs.ParseJSON(max)
+	if response.Aggregations == nil {
+		return nil, errors.New("No aggregations returned")
+	}
+
+	responses := AggregationResponses{}
+
+	for _, aggregation := range aggregations {
+		raw, found := response.Aggregations[aggregation.name]
+
+		if !found {
+			return nil, errors.New("No aggregations returned")
+		}
+
+		container, err := gabs.ParseJSON(raw)
+
+		if err != nil {
+			return nil, err
+		}
+
+		item, err := aggregation.parse(container)
+
+		if err != nil {
+			return nil, err
+		}
+
+		responses[aggregation.name] = item
+	}
+
+	return responses, nil
+}
+
+func (b *Builder) parseMinMaxResponse(responses AggregationResponses, isDateField bool) (*MinMaxResponse, error) {
+	min, err := parseMinMaxValue(responses["min"], isDateField)
 
 	if err != nil {
 		return nil, err
 	}
 
-	val := maxContainer.S(check).Data()
+	max, err := parseMinMaxValue(responses["max"], isDateField)
 
-	if val == nil {
-		return nil, errors.New("Invalid conversion, could not find value")
+	if err != nil {
+		return nil, err
 	}
 
-	response.Max = val
-
-	val = minContainer.S(check).Data()
+	return &MinMaxResponse{Min: min, Max: max}, nil
+}
 
-	if val == nil {
-		return nil, errors.New("Invalid conversion, could not find value")
+func parseMinMaxValue(response *AggregationResponse, isDateField bool) (interface{}, error) {
+	if response != nil && isDateField && len(response.ValueAsString) > 0 {
+		return response.ValueAsString, nil
 	}
 
-	response.Min = val
+	if response != nil && !isDateField && response.Value != nil {
+		return *response.Value, nil
+	}
 
-	return response, nil
+	return nil, errors.New("Invalid conversion, could not find value")
 }
 
 func processWheres(
@@ -827,37 +1808,37 @@ func processWheres(
 	whereNotIns []*whereNotIn,
 ) (terms []elastic.Query, notTerms []elastic.Query) {
 	for _, whereIn := range whereIns {
-		terms = append(terms, elastic.NewTermsQuery(whereIn.Field, whereIn.Values...))
+		terms = append(terms, withClauseOptions(termsQuery{elastic.NewTermsQuery(whereIn.Field, whereIn.Values...)}, whereIn.Options))
 	}
 
 	for _, whereNotIn := range whereNotIns {
-		notTerms = append(notTerms, elastic.NewTermsQuery(whereNotIn.Field, whereNotIn.Values...))
+		notTerms = append(notTerms, withClauseOptions(termsQuery{elastic.NewTermsQuery(whereNotIn.Field, whereNotIn.Values...)}, whereNotIn.Options))
 	}
 
 	for _, where := range wheres {
 		if where.Operand == "=" {
-			terms = append(terms, elastic.NewTermQuery(where.Field, where.Value))
+			terms = append(terms, withClauseOptions(termQuery{elastic.NewTermQuery(where.Field, where.Value)}, where.Options))
 			continue
 		}
 
 		if where.Operand == "<>" {
-			notTerms = append(notTerms, elastic.NewTermQuery(where.Field, where.Value))
+			notTerms = append(notTerms, withClauseOptions(termQuery{elastic.NewTermQuery(where.Field, where.Value)}, where.Options))
 			continue
 		}
 
 		if !where.isString() || where.isDate() {
 			switch where.Operand {
 			case ">":
-				terms = append(terms, elastic.NewRangeQuery(where.Field).Gt(where.Value))
+				terms = append(terms, withClauseOptions(rangeQuery{elastic.NewRangeQuery(where.Field).Gt(where.Value)}, where.Options))
 				break
 			case "<":
-				terms = append(terms, elastic.NewRangeQuery(where.Field).Lt(where.Value))
+				terms = append(terms, withClauseOptions(rangeQuery{elastic.NewRangeQuery(where.Field).Lt(where.Value)}, where.Options))
 				break
 			case ">=":
-				terms = append(terms, elastic.NewRangeQuery(where.Field).Gte(where.Value))
+				terms = append(terms, withClauseOptions(rangeQuery{elastic.NewRangeQuery(where.Field).Gte(where.Value)}, where.Options))
 				break
 			case "<=":
-				terms = append(terms, elastic.NewRangeQuery(where.Field).Lte(where.Value))
+				terms = append(terms, withClauseOptions(rangeQuery{elastic.NewRangeQuery(where.Field).Lte(where.Value)}, where.Options))
 				break
 			}
 		}
@@ -868,28 +1849,28 @@ func processWheres(
 
 func processFilters(filters []*filter, filterIns []*filterIn) (terms []elastic.Query) {
 	for _, filterIn := range filterIns {
-		terms = append(terms, elastic.NewTermsQuery(filterIn.Field, filterIn.Values...))
+		terms = append(terms, withClauseOptions(termsQuery{elastic.NewTermsQuery(filterIn.Field, filterIn.Values...)}, filterIn.Options))
 	}
 
 	for _, filter := range filters {
 		if filter.Operand == "=" {
-			terms = append(terms, elastic.NewTermQuery(filter.Field, filter.Value))
+			terms = append(terms, withClauseOptions(termQuery{elastic.NewTermQuery(filter.Field, filter.Value)}, filter.Options))
 			continue
 		}
 
 		if !filter.isString() || filter.isDate() {
 			switch filter.Operand {
 			case ">":
-				terms = append(terms, elastic.NewRangeQuery(filter.Field).Gt(filter.Value))
+				terms = append(terms, withClauseOptions(rangeQuery{elastic.NewRangeQuery(filter.Field).Gt(filter.Value)}, filter.Options))
 				break
 			case "<":
-				terms = append(terms, elastic.NewRangeQuery(filter.Field).Lt(filter.Value))
+				terms = append(terms, withClauseOptions(rangeQuery{elastic.NewRangeQuery(filter.Field).Lt(filter.Value)}, filter.Options))
 				break
 			case ">=":
-				terms = append(terms, elastic.NewRangeQuery(filter.Field).Gte(filter.Value))
+				terms = append(terms, withClauseOptions(rangeQuery{elastic.NewRangeQuery(filter.Field).Gte(filter.Value)}, filter.Options))
 				break
 			case "<=":
-				terms = append(terms, elastic.NewRangeQuery(filter.Field).Lte(filter.Value))
+				terms = append(terms, withClauseOptions(rangeQuery{elastic.NewRangeQuery(filter.Field).Lte(filter.Value)}, filter.Options))
 				break
 			}
 		}
@@ -898,6 +1879,126 @@ func processFilters(filters []*filter, filterIns []*filterIn) (terms []elastic.Q
 	return terms
 }
 
+func processGeoClauses(clauses *geoClauses) (terms []elastic.Query) {
+	for _, geoDistance := range clauses.geoDistances {
+		terms = append(terms, elastic.NewGeoDistanceQuery(geoDistance.Field).
+			Point(geoDistance.Point.Lat, geoDistance.Point.Lon).
+			Distance(geoDistance.Distance),
+		)
+	}
+
+	for _, geoBoundingBox := range clauses.geoBoundingBoxes {
+		terms = append(terms, elastic.NewGeoBoundingBoxQuery(geoBoundingBox.Field).
+			TopLeft(geoBoundingBox.TopLeft.Lat, geoBoundingBox.TopLeft.Lon).
+			BottomRight(geoBoundingBox.BottomRight.Lat, geoBoundingBox.BottomRight.Lon),
+		)
+	}
+
+	for _, geoPolygon := range clauses.geoPolygons {
+		query := elastic.NewGeoPolygonQuery(geoPolygon.Field)
+
+		for _, point := range geoPolygon.Points {
+			query = query.AddPoint(point.Lat, point.Lon)
+		}
+
+		terms = append(terms, query)
+	}
+
+	for _, geoShape := range clauses.geoShapes {
+		terms = append(terms, newGeoShapeQuery(geoShape.Field, geoShape.Type, geoShape.Coordinates).
+			Relation(geoShape.Relation),
+		)
+	}
+
+	return terms
+}
+
+func processJoinClauses(hasChilds []*hasChild, hasParents []*hasParent, parentIds []*parentId) (terms []elastic.Query) {
+	for _, hasChild := range hasChilds {
+		terms = append(terms, withClauseOptions(
+			hasChildQuery{elastic.NewHasChildQuery(hasChild.Type, joinSubQuery(hasChild.Query))},
+			hasChild.Options,
+		))
+	}
+
+	for _, hasParent := range hasParents {
+		terms = append(terms, withClauseOptions(
+			hasParentQuery{elastic.NewHasParentQuery(hasParent.Type, joinSubQuery(hasParent.Query))},
+			hasParent.Options,
+		))
+	}
+
+	for _, parentId := range parentIds {
+		terms = append(terms, withClauseOptions(
+			parentIdQuery{elastic.NewParentIdQuery(parentId.Type, parentId.Id)},
+			parentId.Options,
+		))
+	}
+
+	return terms
+}
+
+func processDocumentClauses(
+	whereIds []*whereIds,
+	moreLikeThis []*moreLikeThis,
+	whereInLookups []*whereInLookup,
+) (terms []elastic.Query) {
+	for _, ids := range whereIds {
+		terms = append(terms, withClauseOptions(idsQuery{elastic.NewIdsQuery().Ids(ids.Values...)}, ids.Options))
+	}
+
+	for _, lookup := range whereInLookups {
+		terms = append(terms, withClauseOptions(
+			termsQuery{elastic.NewTermsQuery(lookup.Field).TermsLookup(
+				elastic.NewTermsLookup().Index(lookup.Index).Id(lookup.Id).Path(lookup.Path),
+			)},
+			lookup.Options,
+		))
+	}
+
+	for _, mlt := range moreLikeThis {
+		query := elastic.NewMoreLikeThisQuery().Ids(mlt.LikeDocIds...)
+
+		if len(mlt.Fields) > 0 {
+			query = query.Field(mlt.Fields...)
+		}
+
+		if len(mlt.LikeTexts) > 0 {
+			query = query.LikeText(mlt.LikeTexts...)
+		}
+
+		if mlt.Options != nil {
+			if mlt.Options.MinTermFreq > 0 {
+				query = query.MinTermFreq(mlt.Options.MinTermFreq)
+			}
+
+			if mlt.Options.MinDocFreq > 0 {
+				query = query.MinDocFreq(mlt.Options.MinDocFreq)
+			}
+
+			if mlt.Options.MaxQueryTerms > 0 {
+				query = query.MaxQueryTerms(mlt.Options.MaxQueryTerms)
+			}
+
+			if len(mlt.Options.MinimumShouldMatch) > 0 {
+				query = query.MinimumShouldMatch(mlt.Options.MinimumShouldMatch)
+			}
+		}
+
+		terms = append(terms, withClauseOptions(moreLikeThisQuery{query}, mlt.ClauseOptions))
+	}
+
+	return terms
+}
+
+func joinSubQuery(builder *Builder) elastic.Query {
+	if builder == nil {
+		return elastic.NewMatchAllQuery()
+	}
+
+	return builder.query()
+}
+
 func processMatches(
 	matches []*match,
 	matchIns []*matchIn,
@@ -905,23 +2006,23 @@ func processMatches(
 ) (terms []elastic.Query, notTerms []elastic.Query) {
 	for _, matchIn := range matchIns {
 		for _, value := range matchIn.Values {
-			terms = append(terms, elastic.NewMatchQuery(matchIn.Field, value))
+			terms = append(terms, withClauseOptions(matchQuery{elastic.NewMatchQuery(matchIn.Field, value)}, matchIn.Options))
 		}
 	}
 
 	for _, matchNotIn := range matchNotIns {
 		for _, value := range matchNotIn.Values {
-			notTerms = append(notTerms, elastic.NewMatchQuery(matchNotIn.Field, value))
+			notTerms = append(notTerms, withClauseOptions(matchQuery{elastic.NewMatchQuery(matchNotIn.Field, value)}, matchNotIn.Options))
 		}
 	}
 
 	for _, match := range matches {
 		if match.Operand == "=" {
-			terms = append(terms, elastic.NewMatchQuery(match.Field, match.Value))
+			terms = append(terms, withClauseOptions(matchQuery{elastic.NewMatchQuery(match.Field, match.Value)}, match.Options))
 		}
 
 		if match.Operand == "<>" {
-			notTerms = append(notTerms, elastic.NewMatchQuery(match.Field, match.Value))
+			notTerms = append(notTerms, withClauseOptions(matchQuery{elastic.NewMatchQuery(match.Field, match.Value)}, match.Options))
 		}
 	}
 
@@ -935,25 +2036,149 @@ func processMatchPhrases(
 ) (terms []elastic.Query, notTerms []elastic.Query) {
 	for _, matchPhraseIn := range matchPhraseIns {
 		for _, value := range matchPhraseIn.Values {
-			terms = append(terms, elastic.NewMatchPhraseQuery(matchPhraseIn.Field, value))
+			terms = append(terms, withClauseOptions(matchPhraseQuery{elastic.NewMatchPhraseQuery(matchPhraseIn.Field, value)}, matchPhraseIn.Options))
 		}
 	}
 
 	for _, matchPhraseNotIn := range matchPhraseNotIns {
 		for _, value := range matchPhraseNotIn.Values {
-			notTerms = append(notTerms, elastic.NewMatchPhraseQuery(matchPhraseNotIn.Field, value))
+			notTerms = append(notTerms, withClauseOptions(matchPhraseQuery{elastic.NewMatchPhraseQuery(matchPhraseNotIn.Field, value)}, matchPhraseNotIn.Options))
 		}
 	}
 
 	for _, matchPhrase := range matchPhrases {
 		if matchPhrase.Operand == "=" {
-			terms = append(terms, elastic.NewMatchPhraseQuery(matchPhrase.Field, matchPhrase.Value))
+			terms = append(terms, withClauseOptions(matchPhraseQuery{elastic.NewMatchPhraseQuery(matchPhrase.Field, matchPhrase.Value)}, matchPhrase.Options))
 		}
 
 		if matchPhrase.Operand == "<>" {
-			notTerms = append(notTerms, elastic.NewMatchPhraseQuery(matchPhrase.Field, matchPhrase.Value))
+			notTerms = append(notTerms, withClauseOptions(matchPhraseQuery{elastic.NewMatchPhraseQuery(matchPhrase.Field, matchPhrase.Value)}, matchPhrase.Options))
 		}
 	}
 
 	return terms, notTerms
 }
+
+// optionQuery is implemented by the elastic queries that support the clause options, each
+// elastic setter returns its own query type so every query is wrapped in order to satisfy it
+type optionQuery interface {
+	elastic.Query
+	boost(boost float64)
+	queryName(name string)
+}
+
+func withClauseOptions(query optionQuery, options *clauseOptions) elastic.Query {
+	if options == nil {
+		return query
+	}
+
+	if options.Boost != nil {
+		query.boost(*options.Boost)
+	}
+
+	if len(options.Name) > 0 {
+		query.queryName(options.Name)
+	}
+
+	return query
+}
+
+type idsQuery struct{ *elastic.IdsQuery }
+
+func (q idsQuery) boost(boost float64) {
+	q.Boost(boost)
+}
+
+func (q idsQuery) queryName(name string) {
+	q.QueryName(name)
+}
+
+type moreLikeThisQuery struct{ *elastic.MoreLikeThisQuery }
+
+func (q moreLikeThisQuery) boost(boost float64) {
+	q.Boost(boost)
+}
+
+func (q moreLikeThisQuery) queryName(name string) {
+	q.QueryName(name)
+}
+
+type termQuery struct{ *elastic.TermQuery }
+
+func (q termQuery) boost(boost float64) {
+	q.Boost(boost)
+}
+
+func (q termQuery) queryName(name string) {
+	q.QueryName(name)
+}
+
+type termsQuery struct{ *elastic.TermsQuery }
+
+func (q termsQuery) boost(boost float64) {
+	q.Boost(boost)
+}
+
+func (q termsQuery) queryName(name string) {
+	q.QueryName(name)
+}
+
+type rangeQuery struct{ *elastic.RangeQuery }
+
+func (q rangeQuery) boost(boost float64) {
+	q.Boost(boost)
+}
+
+func (q rangeQuery) queryName(name string) {
+	q.QueryName(name)
+}
+
+type matchQuery struct{ *elastic.MatchQuery }
+
+func (q matchQuery) boost(boost float64) {
+	q.Boost(boost)
+}
+
+func (q matchQuery) queryName(name string) {
+	q.QueryName(name)
+}
+
+type matchPhraseQuery struct{ *elastic.MatchPhraseQuery }
+
+func (q matchPhraseQuery) boost(boost float64) {
+	q.Boost(boost)
+}
+
+func (q matchPhraseQuery) queryName(name string) {
+	q.QueryName(name)
+}
+
+type hasChildQuery struct{ *elastic.HasChildQuery }
+
+func (q hasChildQuery) boost(boost float64) {
+	q.Boost(boost)
+}
+
+func (q hasChildQuery) queryName(name string) {
+	q.QueryName(name)
+}
+
+type hasParentQuery struct{ *elastic.HasParentQuery }
+
+func (q hasParentQuery) boost(boost float64) {
+	q.Boost(boost)
+}
+
+func (q hasParentQuery) queryName(name string) {
+	q.QueryName(name)
+}
+
+type parentIdQuery struct{ *elastic.ParentIdQuery }
+
+func (q parentIdQuery) boost(boost float64) {
+	q.Boost(boost)
+}
+
+func (q parentIdQuery) queryName(name string) {
+	q.QueryName(name)
+}
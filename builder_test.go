@@ -137,6 +137,54 @@ func TestFind(t *testing.T) {
 	}
 }
 
+func TestFindMany(t *testing.T) {
+	connection, err := initConnection()
+
+	if err != nil {
+		t.Error("Expected no error got:", err)
+	}
+
+	if _, err = connection.Builder("example").Insert(seedModels(3)...); err != nil {
+		t.Error("Expected no error on insert:", err)
+	}
+
+	builder := connection.Builder("example")
+	builder.Exclude("description")
+
+	response := []*Example{}
+
+	result, err := builder.FindMany([]string{"3", "404", "1"}, &response)
+
+	if err != nil {
+		t.Error("Expected no error got:", err)
+	}
+
+	assert.Equal(t, 2, len(response))
+	assert.Equal(t, "3", response[0].Id)
+	assert.Equal(t, "1", response[1].Id)
+	assert.Equal(t, "", response[0].Description)
+	assert.Equal(t, 1, len(result.Missing))
+	assert.Equal(t, "404", result.Missing[0].Id)
+
+	documents := []*DocumentId{{Id: "2"}, {Id: "2", Index: "missing_index"}}
+
+	result, err = connection.Builder("example").FindDocuments(documents, &response)
+
+	if err != nil {
+		t.Error("Expected no error got:", err)
+	}
+
+	assert.Equal(t, 1, len(response))
+	assert.Equal(t, 0, len(result.Missing))
+	assert.Equal(t, 1, len(result.Errors))
+	assert.Equal(t, "missing_index", result.Errors[0].Document.Index)
+	assert.Equal(t, "index_not_found_exception", result.Errors[0].Type)
+
+	if err := tearDownBuilder(connection); err != nil {
+		t.Error("Expected no error got:", err)
+	}
+}
+
 func TestUpdateByQuery(t *testing.T) {
 	connection, err := initConnection()
 
@@ -788,6 +836,117 @@ func TestMinMax(t *testing.T) {
 	assert.Equal(t, 1, int(result.Min.(float64)))
 	assert.Equal(t, 2, int(result.Max.(float64)))
 
+	builder = connection.Builder("example")
+	builder.Where("subject_id", "=", 2)
+
+	result, err = builder.MinMax("subject_id", false)
+
+	if err != nil {
+		t.Error("Expected no error on aggs query:", err)
+	}
+
+	assert.Equal(t, 2, int(result.Min.(float64)))
+	assert.Equal(t, 2, int(result.Max.(float64)))
+
+	if err := tearDownBuilder(connection); err != nil {
+		t.Error("Expected no error got:", err)
+	}
+}
+
+func TestScalarAggregates(t *testing.T) {
+	connection, err := initConnection()
+
+	if err != nil {
+		t.Error("Expected no error on insert:", err)
+	}
+
+	builder := connection.Builder("example")
+
+	if _, err = builder.Insert(seedModels(15)...); err != nil {
+		t.Error("Expected no error on insert:", err)
+	}
+
+	time.Sleep(1 * time.Second)
+
+	builder = connection.Builder("example")
+
+	sum, err := builder.Sum("subject_id")
+
+	if err != nil {
+		t.Error("Expected no error on aggs query:", err)
+	}
+
+	assert.Equal(t, float64(20), *sum)
+
+	countDistinct, err := builder.CountDistinct("subject_id")
+
+	if err != nil {
+		t.Error("Expected no error on aggs query:", err)
+	}
+
+	assert.Equal(t, int64(2), countDistinct)
+
+	distinct, err := builder.Distinct("subject_id")
+
+	if err != nil {
+		t.Error("Expected no error on aggs query:", err)
+	}
+
+	assert.Equal(t, []interface{}{json.Number("1"), json.Number("2")}, distinct)
+
+	builder.Where("subject_id", "=", 2)
+
+	avg, err := builder.Avg("subject_id")
+
+	if err != nil {
+		t.Error("Expected no error on aggs query:", err)
+	}
+
+	assert.Equal(t, float64(2), *avg)
+
+	min, err := builder.Min("subject_id")
+
+	if err != nil {
+		t.Error("Expected no error on aggs query:", err)
+	}
+
+	assert.Equal(t, float64(2), *min)
+
+	max, err := builder.Max("subject_id")
+
+	if err != nil {
+		t.Error("Expected no error on aggs query:", err)
+	}
+
+	assert.Equal(t, float64(2), *max)
+
+	exists, err := builder.Exists()
+
+	if err != nil {
+		t.Error("Expected no error on exists query:", err)
+	}
+
+	assert.True(t, exists)
+
+	builder = connection.Builder("example")
+	builder.Where("subject_id", "=", 3)
+
+	exists, err = builder.Exists()
+
+	if err != nil {
+		t.Error("Expected no error on exists query:", err)
+	}
+
+	assert.False(t, exists)
+
+	avg, err = builder.Avg("subject_id")
+
+	if err != nil {
+		t.Error("Expected no error on aggs query:", err)
+	}
+
+	assert.Nil(t, avg)
+
 	if err := tearDownBuilder(connection); err != nil {
 		t.Error("Expected no error got:", err)
 	}
@@ -903,6 +1062,301 @@ func TestNestedSort(t *testing.T) {
 	}
 }
 
+func TestSelect(t *testing.T) {
+	connection, err := initConnection()
+
+	if err != nil {
+		t.Error("Expected no error got:", err)
+	}
+
+	builder := connection.Builder("example")
+
+	if _, err = builder.Insert(seedModels(5)...); err != nil {
+		t.Error("Expected no error on insert:", err)
+	}
+
+	time.Sleep(1 * time.Second)
+
+	builder.Select("id", "subject_id").Exclude("description").OrderBy("id", true)
+
+	examples := []Example{}
+
+	if err := builder.Get(&examples); err != nil {
+		t.Error("Expected no error got:", err)
+	}
+
+	assert.Equal(t, 5, len(examples))
+	assert.Equal(t, "1", examples[0].Id)
+	assert.Equal(t, 1, examples[0].SubjectId)
+	assert.Equal(t, "", examples[0].Description)
+
+	var example Example
+
+	builder = connection.Builder("example")
+	builder.Exclude("description")
+
+	if err := builder.Find("2", &example); err != nil {
+		t.Error("Expected no error got:", err)
+	}
+
+	assert.Equal(t, "2", example.Id)
+	assert.Equal(t, "", example.Description)
+
+	if err := tearDownBuilder(connection); err != nil {
+		t.Error("Expected no error got:", err)
+	}
+}
+
+func TestPluck(t *testing.T) {
+	connection, err := initConnection()
+
+	if err != nil {
+		t.Error("Expected no error got:", err)
+	}
+
+	builder := connection.Builder("example")
+
+	if _, err = builder.Insert(seedModels(3)...); err != nil {
+		t.Error("Expected no error on insert:", err)
+	}
+
+	time.Sleep(1 * time.Second)
+
+	builder.OrderBy("id", true)
+
+	descriptions, err := builder.Pluck("description")
+
+	if err != nil {
+		t.Error("Expected no error got:", err)
+	}
+
+	assert.Equal(t, []interface{}{"Description 1", "Description 2", "Description 3"}, descriptions)
+	assert.Nil(t, builder.sourceFilter)
+
+	examples := []Example{}
+
+	if err := builder.Get(&examples); err != nil {
+		t.Error("Expected no error got:", err)
+	}
+
+	assert.Equal(t, 1, examples[0].SubjectId)
+
+	if err := tearDownBuilder(connection); err != nil {
+		t.Error("Expected no error got:", err)
+	}
+}
+
+func TestWith(t *testing.T) {
+	connection, err := initConnection()
+
+	if err != nil {
+		t.Error("Expected no error got:", err)
+	}
+
+	builder := connection.Builder("example")
+
+	if _, err = builder.Insert(seedModels(3)...); err != nil {
+		t.Error("Expected no error on insert:", err)
+	}
+
+	time.Sleep(1 * time.Second)
+
+	type ExampleWithSubject struct {
+		Example
+		Subjects []*Example `json:"subjects"`
+	}
+
+	builder.With(Relation{
+		Name:     "subjects",
+		Index:    "example",
+		LocalKey: "subject_id",
+	}).WhereInLookup("id", "example", "2", "subject_id")
+
+	response := []*ExampleWithSubject{}
+
+	if err := builder.Get(&response); err != nil {
+		t.Error("Expected no error got:", err)
+	}
+
+	assert.Equal(t, 1, len(response))
+	assert.Equal(t, "2", response[0].Id)
+	assert.Equal(t, 1, len(response[0].Subjects))
+	assert.Equal(t, "2", response[0].Subjects[0].Id)
+
+	if err := tearDownBuilder(connection); err != nil {
+		t.Error("Expected no error got:", err)
+	}
+}
+
+func TestMultipleIndices(t *testing.T) {
+	connection, err := initConnection()
+
+	if err != nil {
+		t.Error("Expected no error got:", err)
+	}
+
+	if err := connection.Indexer(nil).CreateIndex("example_archive", indexConfig()); err != nil {
+		t.Error("Expected no error got:", err)
+	}
+
+	models := seedModels(2)
+
+	if _, err = connection.Builder("example").Insert(models[0]); err != nil {
+		t.Error("Expected no error on insert:", err)
+	}
+
+	if _, err = connection.Builder("example_archive").Insert(models[1]); err != nil {
+		t.Error("Expected no error on insert:", err)
+	}
+
+	time.Sleep(1 * time.Second)
+
+	builder := connection.Builder("example", "example_*", "<missing-{now/d}>")
+	builder.IndicesOptions(true, true).IndexBoost("example_archive", 2)
+
+	response := []*Example{}
+
+	result, err := builder.Search(&response)
+
+	if err != nil {
+		t.Error("Expected no error got:", err)
+	}
+
+	assert.Equal(t, 2, len(result.Hits))
+	assert.Equal(t, "example_archive", result.Hits[0].Index)
+	assert.Equal(t, "example", result.Hits[1].Index)
+
+	if err := connection.Indexer(nil).DeleteIndex("example_archive"); err != nil {
+		t.Error("Expected no error got:", err)
+	}
+
+	if err := tearDownBuilder(connection); err != nil {
+		t.Error("Expected no error got:", err)
+	}
+}
+
+func TestDocumentRouting(t *testing.T) {
+	builder := new(Builder)
+
+	routing, err := builder.documentRouting(seedModels(1)[0])
+
+	assert.Nil(t, err)
+	assert.Equal(t, "", routing)
+
+	builder.RouteBy(RoutingField("subject_id"))
+
+	routing, err = builder.documentRouting(map[string]interface{}{"subject_id": 12345678901})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "12345678901", routing)
+
+	if _, err := builder.documentRouting(map[string]interface{}{"id": "1"}); err == nil {
+		t.Error("Expected errors but got ", err)
+	}
+
+	child := new(Builder).JoinRelation("relation", "line", "order-1")
+
+	routing, err = child.joinRouting(child.routing)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "order-1", routing)
+
+	child.Routing("tenant-1")
+
+	if _, err := child.joinRouting(child.routing); err == nil {
+		t.Error("Expected errors but got ", err)
+	}
+
+	if err := child.Find("1", &Example{}); err == nil {
+		t.Error("Expected errors but got ", err)
+	}
+
+	if _, err := child.FindDocuments([]*DocumentId{{Id: "1"}}, &[]Example{}); err == nil {
+		t.Error("Expected errors but got ", err)
+	}
+
+	if _, err := child.Delete("1"); err == nil {
+		t.Error("Expected errors but got ", err)
+	}
+
+	child.Routing("").RouteBy(RoutingField("subject_id"))
+
+	if _, err := child.documentRouting(map[string]interface{}{"subject_id": 12345678901}); err == nil {
+		t.Error("Expected errors but got ", err)
+	}
+}
+
+func TestRouting(t *testing.T) {
+	connection, err := initConnection()
+
+	if err != nil {
+		t.Error("Expected no error got:", err)
+	}
+
+	builder := connection.Builder("example").RouteBy(RoutingField("subject_id"))
+
+	if _, err = builder.Insert(seedModels(2)...); err != nil {
+		t.Error("Expected no error on insert:", err)
+	}
+
+	time.Sleep(1 * time.Second)
+
+	var response Example
+
+	if err = connection.Builder("example").Routing("1").Preference("_local").Find("1", &response); err != nil {
+		t.Error("Expected no error got:", err)
+	}
+
+	assert.Equal(t, "1", response.Id)
+
+	count, err := connection.Builder("example").Routing("1").Count()
+
+	if err != nil {
+		t.Error("Expected no error got:", err)
+	}
+
+	assert.Equal(t, int64(2), count)
+
+	if _, err := connection.Builder("example").Routing("1").Delete("1", "2"); err != nil {
+		t.Error("Expected no error got:", err)
+	}
+
+	if err := tearDownBuilder(connection); err != nil {
+		t.Error("Expected no error got:", err)
+	}
+}
+
+func TestGeoDistance(t *testing.T) {
+	connection, err := initGeoConnection()
+
+	if err != nil {
+		t.Error("Expected no error got:", err)
+	}
+
+	builder := connection.Builder("stores")
+
+	builder.WhereGeoDistance("location", 40.7128, -74.0060, "50km").
+		OrderByGeoDistance("location", 40.7128, -74.0060, "km")
+
+	stores := []Store{}
+
+	response, err := builder.Search(&stores)
+
+	if err != nil {
+		t.Error("Expected no error got:", err)
+	}
+
+	assert.Equal(t, 2, len(stores))
+	assert.Equal(t, "manhattan", stores[0].Id)
+	assert.Equal(t, "newark", stores[1].Id)
+	assert.Equal(t, 2, len(response.Hits))
+	assert.True(t, *response.Hits[0].Distance < *response.Hits[1].Distance)
+
+	if err := connection.Indexer(nil).DeleteIndex("stores"); err != nil {
+		t.Error(err)
+	}
+}
+
 func initConnection() (*Connection, error) {
 	connection, err := bootConnection()
 
@@ -1098,3 +1552,51 @@ func makeVariants(count int) []interface{} {
 
 	return variants
 }
+
+type Store struct {
+	Id       string   `json:"id"`
+	Location GeoPoint `json:"location"`
+}
+
+func initGeoConnection() (*Connection, error) {
+	connection, err := bootConnection()
+
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := toJson(map[string]interface{}{
+		"mappings": map[string]interface{}{
+			"properties": map[string]interface{}{
+				"id": map[string]interface{}{
+					"type": "keyword",
+				},
+				"location": map[string]interface{}{
+					"type": "geo_point",
+				},
+			},
+		},
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := connection.Indexer(nil).CreateIndex("stores", schema); err != nil {
+		return nil, err
+	}
+
+	stores := []interface{}{
+		&Store{Id: "manhattan", Location: GeoPoint{Lat: 40.7831, Lon: -73.9712}},
+		&Store{Id: "newark", Location: GeoPoint{Lat: 40.7357, Lon: -74.1724}},
+		&Store{Id: "boston", Location: GeoPoint{Lat: 42.3601, Lon: -71.0589}},
+	}
+
+	if _, err := connection.Builder("stores").Insert(stores...); err != nil {
+		return nil, err
+	}
+
+	time.Sleep(1 * time.Second)
+
+	return connection, nil
+}
@@ -1,11 +1,103 @@
 package golastic
 
-import "errors"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ClauseOption sets an option such as the boost or the name of a clause
+type ClauseOption func(*clauseOptions)
+
+// Boost sets the boost of a clause in order to weight its contribution to the score
+func Boost(boost float64) ClauseOption {
+	return func(co *clauseOptions) {
+		co.Boost = &boost
+	}
+}
+
+// Name names a clause, the names of the clauses that matched a hit
+// are reported on the hit's matched queries
+func Name(name string) ClauseOption {
+	return func(co *clauseOptions) {
+		co.Name = name
+	}
+}
+
+// RoutingFunc extracts the routing value of a document being written
+type RoutingFunc func(item interface{}) (string, error)
+
+// RoutingField returns a RoutingFunc that routes each document by the value of the given field
+func RoutingField(field string) RoutingFunc {
+	return func(item interface{}) (string, error) {
+		data, err := json.Marshal(item)
+
+		if err != nil {
+			return "", err
+		}
+
+		doc, err := parseSource(data)
+
+		if err != nil {
+			return "", err
+		}
+
+		value := doc.Path(field).Data()
+
+		if value == nil {
+			return "", errors.New("No routing value found for field " + field)
+		}
+
+		return fmt.Sprint(value), nil
+	}
+}
+
+type clauseOptions struct {
+	Boost *float64
+	Name  string
+}
+
+func newClauseOptions(options []ClauseOption) *clauseOptions {
+	if len(options) == 0 {
+		return nil
+	}
+
+	clauseOptions := &clauseOptions{}
+
+	for _, option := range options {
+		option(clauseOptions)
+	}
+
+	return clauseOptions
+}
+
+func (co *clauseOptions) validate() error {
+	if co == nil {
+		return nil
+	}
+
+	if co.Boost != nil && *co.Boost < 0 {
+		return errors.New("The boost needs to be greater or equal to 0.")
+	}
+
+	return nil
+}
+
+// validateUnscored validates the options of the filter and negated clauses,
+// which cannot be boosted since they do not contribute to the score
+func (co *clauseOptions) validateUnscored() error {
+	if co != nil && co.Boost != nil {
+		return errors.New("Filter and negated clauses do not contribute to the score, hence they cannot be boosted.")
+	}
+
+	return co.validate()
+}
 
 type where struct {
 	Field   string
 	Operand string
 	Value   interface{}
+	Options *clauseOptions
 }
 
 func (w *where) validate() error {
@@ -23,7 +115,11 @@ func (w *where) validate() error {
 		}
 	}
 
-	return nil
+	if w.Operand == "<>" {
+		return w.Options.validateUnscored()
+	}
+
+	return w.Options.validate()
 }
 
 func (w *where) isString() bool {
@@ -35,24 +131,28 @@ func (w *where) isDate() bool {
 }
 
 type whereIn struct {
-	Field  string
-	Values []interface{}
+	Field   string
+	Values  []interface{}
+	Options *clauseOptions
 }
 
 func (wi *whereIn) validate() error {
-	for _, value := range wi.Values {
-		if !isNumeric(value) && !isString(value) {
-			return errors.New("The value is not numeric nor a string.")
-		}
-	}
-
-	return nil
+	return validateInClause(wi.Field, wi.Values, wi.Options)
 }
 
 type whereNotIn struct {
 	whereIn
-	Field  string
-	Values []interface{}
+	Field   string
+	Values  []interface{}
+	Options *clauseOptions
+}
+
+func (wni *whereNotIn) validate() error {
+	if err := validateInClause(wni.Field, wni.Values, wni.Options); err != nil {
+		return err
+	}
+
+	return wni.Options.validateUnscored()
 }
 
 type filter struct {
@@ -60,6 +160,7 @@ type filter struct {
 	Field   string
 	Operand string
 	Value   interface{}
+	Options *clauseOptions
 }
 
 func (f *filter) validate() error {
@@ -77,13 +178,22 @@ func (f *filter) validate() error {
 		}
 	}
 
-	return nil
+	return f.Options.validateUnscored()
 }
 
 type filterIn struct {
 	whereIn
-	Field  string
-	Values []interface{}
+	Field   string
+	Values  []interface{}
+	Options *clauseOptions
+}
+
+func (fi *filterIn) validate() error {
+	if err := validateInClause(fi.Field, fi.Values, fi.Options); err != nil {
+		return err
+	}
+
+	return fi.Options.validateUnscored()
 }
 
 type match struct {
@@ -91,6 +201,7 @@ type match struct {
 	Field   string
 	Operand string
 	Value   interface{}
+	Options *clauseOptions
 }
 
 func (m *match) validate() error {
@@ -108,19 +219,37 @@ func (m *match) validate() error {
 		}
 	}
 
-	return nil
+	if m.Operand == "<>" {
+		return m.Options.validateUnscored()
+	}
+
+	return m.Options.validate()
 }
 
 type matchIn struct {
 	whereIn
-	Field  string
-	Values []interface{}
+	Field   string
+	Values  []interface{}
+	Options *clauseOptions
+}
+
+func (mi *matchIn) validate() error {
+	return validateInClause(mi.Field, mi.Values, mi.Options)
 }
 
 type matchNotIn struct {
 	whereIn
-	Field  string
-	Values []interface{}
+	Field   string
+	Values  []interface{}
+	Options *clauseOptions
+}
+
+func (mni *matchNotIn) validate() error {
+	if err := validateInClause(mni.Field, mni.Values, mni.Options); err != nil {
+		return err
+	}
+
+	return mni.Options.validateUnscored()
 }
 
 type matchPhrase struct {
@@ -128,6 +257,7 @@ type matchPhrase struct {
 	Field   string
 	Operand string
 	Value   interface{}
+	Options *clauseOptions
 }
 
 func (mp *matchPhrase) validate() error {
@@ -145,19 +275,53 @@ func (mp *matchPhrase) validate() error {
 		}
 	}
 
-	return nil
+	if mp.Operand == "<>" {
+		return mp.Options.validateUnscored()
+	}
+
+	return mp.Options.validate()
 }
 
 type matchPhraseIn struct {
 	matchIn
-	Field  string
-	Values []interface{}
+	Field   string
+	Values  []interface{}
+	Options *clauseOptions
+}
+
+func (mpi *matchPhraseIn) validate() error {
+	return validateInClause(mpi.Field, mpi.Values, mpi.Options)
 }
 
 type matchPhraseNotIn struct {
 	matchNotIn
-	Field  string
-	Values []interface{}
+	Field   string
+	Values  []interface{}
+	Options *clauseOptions
+}
+
+func (mpni *matchPhraseNotIn) validate() error {
+	if err := validateInClause(mpni.Field, mpni.Values, mpni.Options); err != nil {
+		return err
+	}
+
+	return mpni.Options.validateUnscored()
+}
+
+// validateInClause validates the clauses matching a list of values, the types embedding whereIn
+// shadow its fields and therefore need to validate their own fields through their own validate method
+func validateInClause(field string, values []interface{}, options *clauseOptions) error {
+	if len(field) == 0 {
+		return errors.New("field cannot be empty")
+	}
+
+	for _, value := range values {
+		if !isNumeric(value) && !isString(value) {
+			return errors.New("The value is not numeric nor a string.")
+		}
+	}
+
+	return options.validate()
 }
 
 type sort struct {
@@ -177,6 +341,16 @@ type groupBy struct {
 	Fields []string
 }
 
+type indicesOptions struct {
+	IgnoreUnavailable bool
+	AllowNoIndices    bool
+}
+
+type sourceFilter struct {
+	Includes []string
+	Excludes []string
+}
+
 type nestedSort struct {
 	Field string
 	Path  string
@@ -198,3 +372,318 @@ func (ns *nestedSort) validate() error {
 
 	return nil
 }
+
+// GeoPoint represents a latitude and longitude pair
+type GeoPoint struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+func (gp *GeoPoint) validate() error {
+	if gp.Lat < -90 || gp.Lat > 90 {
+		return errors.New("The latitude needs to be between -90 and 90.")
+	}
+
+	if gp.Lon < -180 || gp.Lon > 180 {
+		return errors.New("The longitude needs to be between -180 and 180.")
+	}
+
+	return nil
+}
+
+type geoDistance struct {
+	Field    string
+	Point    GeoPoint
+	Distance string
+}
+
+func (gd *geoDistance) validate() error {
+	if len(gd.Field) == 0 {
+		return errors.New("field cannot be empty")
+	}
+
+	if len(gd.Distance) == 0 {
+		return errors.New("distance cannot be empty")
+	}
+
+	return gd.Point.validate()
+}
+
+type geoBoundingBox struct {
+	Field       string
+	TopLeft     GeoPoint
+	BottomRight GeoPoint
+}
+
+func (gbb *geoBoundingBox) validate() error {
+	if len(gbb.Field) == 0 {
+		return errors.New("field cannot be empty")
+	}
+
+	if err := gbb.TopLeft.validate(); err != nil {
+		return err
+	}
+
+	if err := gbb.BottomRight.validate(); err != nil {
+		return err
+	}
+
+	if gbb.TopLeft.Lat < gbb.BottomRight.Lat {
+		return errors.New("The top left latitude needs to be greater or equal to the bottom right latitude.")
+	}
+
+	return nil
+}
+
+type geoPolygon struct {
+	Field  string
+	Points []GeoPoint
+}
+
+func (gp *geoPolygon) validate() error {
+	if len(gp.Field) == 0 {
+		return errors.New("field cannot be empty")
+	}
+
+	if len(gp.Points) < 3 {
+		return errors.New("A polygon needs at least 3 points.")
+	}
+
+	for _, point := range gp.Points {
+		if err := point.validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type geoShape struct {
+	Field       string
+	Type        string
+	Coordinates interface{}
+	Relation    string
+}
+
+func (gs *geoShape) validate() error {
+	if len(gs.Field) == 0 {
+		return errors.New("field cannot be empty")
+	}
+
+	if !inSlice(gs.Type, "point", "linestring", "polygon", "multipoint", "multilinestring", "multipolygon", "envelope") {
+		return errors.New("The shape type is invalid.")
+	}
+
+	if gs.Coordinates == nil {
+		return errors.New("coordinates cannot be empty")
+	}
+
+	if len(gs.Relation) > 0 && !inSlice(gs.Relation, "intersects", "disjoint", "within", "contains") {
+		return errors.New("The shape relation is invalid.")
+	}
+
+	return nil
+}
+
+type geoDistanceSort struct {
+	Field string
+	Point GeoPoint
+	Unit  string
+}
+
+func (gds *geoDistanceSort) validate() error {
+	if len(gds.Field) == 0 {
+		return errors.New("field cannot be empty")
+	}
+
+	if len(gds.Unit) > 0 && !inSlice(gds.Unit, "mi", "yd", "ft", "in", "km", "m", "cm", "mm", "nmi") {
+		return errors.New("The distance unit is invalid.")
+	}
+
+	return gds.Point.validate()
+}
+
+type hasChild struct {
+	Type    string
+	Query   *Builder
+	Options *clauseOptions
+}
+
+func (hc *hasChild) validate() error {
+	if len(hc.Type) == 0 {
+		return errors.New("type cannot be empty")
+	}
+
+	if hc.Query != nil {
+		if err := hc.Query.validateMustClauses(); err != nil {
+			return err
+		}
+	}
+
+	return hc.Options.validate()
+}
+
+type hasParent struct {
+	Type    string
+	Query   *Builder
+	Options *clauseOptions
+}
+
+func (hp *hasParent) validate() error {
+	if len(hp.Type) == 0 {
+		return errors.New("type cannot be empty")
+	}
+
+	if hp.Query != nil {
+		if err := hp.Query.validateMustClauses(); err != nil {
+			return err
+		}
+	}
+
+	return hp.Options.validate()
+}
+
+type parentId struct {
+	Type    string
+	Id      string
+	Options *clauseOptions
+}
+
+func (pi *parentId) validate() error {
+	if len(pi.Type) == 0 {
+		return errors.New("type cannot be empty")
+	}
+
+	if len(pi.Id) == 0 {
+		return errors.New("id cannot be empty")
+	}
+
+	return pi.Options.validate()
+}
+
+type joinRelation struct {
+	Field  string
+	Name   string
+	Parent string
+}
+
+func (jr *joinRelation) validate() error {
+	if len(jr.Field) == 0 {
+		return errors.New("field cannot be empty")
+	}
+
+	if len(jr.Name) == 0 {
+		return errors.New("name cannot be empty")
+	}
+
+	return nil
+}
+
+func (jr *joinRelation) value() interface{} {
+	if len(jr.Parent) == 0 {
+		return jr.Name
+	}
+
+	return map[string]interface{}{
+		"name":   jr.Name,
+		"parent": jr.Parent,
+	}
+}
+
+type whereIds struct {
+	Values  []string
+	Options *clauseOptions
+}
+
+func (wi *whereIds) validate() error {
+	if len(wi.Values) == 0 {
+		return errors.New("ids cannot be empty")
+	}
+
+	for _, id := range wi.Values {
+		if len(id) == 0 {
+			return errors.New("id cannot be empty")
+		}
+	}
+
+	return wi.Options.validate()
+}
+
+// MoreLikeThisOptions represents the optional term selection
+// settings of a more like this clause, zero values are ignored
+type MoreLikeThisOptions struct {
+	MinTermFreq        int
+	MinDocFreq         int
+	MaxQueryTerms      int
+	MinimumShouldMatch string
+}
+
+type moreLikeThis struct {
+	Fields        []string
+	LikeTexts     []string
+	LikeDocIds    []string
+	Options       *MoreLikeThisOptions
+	ClauseOptions *clauseOptions
+}
+
+func (mlt *moreLikeThis) validate() error {
+	if len(mlt.LikeTexts) == 0 && len(mlt.LikeDocIds) == 0 {
+		return errors.New("Please specify at least a like text or a like document id.")
+	}
+
+	for _, field := range mlt.Fields {
+		if len(field) == 0 {
+			return errors.New("field cannot be empty")
+		}
+	}
+
+	if mlt.Options != nil && (mlt.Options.MinTermFreq < 0 || mlt.Options.MinDocFreq < 0 || mlt.Options.MaxQueryTerms < 0) {
+		return errors.New("The more like this term frequencies and max query terms cannot be negative.")
+	}
+
+	return mlt.ClauseOptions.validate()
+}
+
+type whereInLookup struct {
+	Field   string
+	Index   string
+	Id      string
+	Path    string
+	Options *clauseOptions
+}
+
+func (wil *whereInLookup) validate() error {
+	if len(wil.Field) == 0 {
+		return errors.New("field cannot be empty")
+	}
+
+	if len(wil.Index) == 0 {
+		return errors.New("index cannot be empty")
+	}
+
+	if len(wil.Id) == 0 {
+		return errors.New("id cannot be empty")
+	}
+
+	if len(wil.Path) == 0 {
+		return errors.New("path cannot be empty")
+	}
+
+	return wil.Options.validate()
+}
+
+// DocumentId identifies a document to be retrieved by FindDocuments, an empty
+// index defaults to the builder's index and the routing is optional
+type DocumentId struct {
+	Id      string
+	Index   string
+	Routing string
+}
+
+func (di *DocumentId) validate() error {
+	if len(di.Id) == 0 {
+		return errors.New("id cannot be empty")
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package golastic
+
+import (
+	"errors"
+
+	elastic "github.com/alejandro-carstens/elasticfork"
+)
+
+// Collapse represents the struct in charge of configuring field collapsing,
+// which returns only the top hit for each distinct value of a field
+type Collapse struct {
+	field                      string
+	innerHits                  *InnerHits
+	maxConcurrentGroupSearches *int
+}
+
+// InnerHits retrieves the top hits of each collapsed group under the given name
+func (c *Collapse) InnerHits(name string) *InnerHits {
+	c.innerHits = &InnerHits{name: name}
+
+	return c.innerHits
+}
+
+// MaxConcurrentGroupSearches sets the number of concurrent requests allowed
+// to retrieve the inner hits of the collapsed groups
+func (c *Collapse) MaxConcurrentGroupSearches(max int) *Collapse {
+	c.maxConcurrentGroupSearches = &max
+
+	return c
+}
+
+func (c *Collapse) validate() error {
+	if len(c.field) == 0 {
+		return errors.New("field cannot be empty")
+	}
+
+	if c.maxConcurrentGroupSearches != nil && *c.maxConcurrentGroupSearches <= 0 {
+		return errors.New("The max concurrent group searches needs to be greater than 0.")
+	}
+
+	if c.innerHits == nil {
+		return nil
+	}
+
+	if len(c.innerHits.name) == 0 {
+		return errors.New("Collapse inner hits require a name.")
+	}
+
+	return c.innerHits.validate()
+}
+
+func (c *Collapse) collapse() *elastic.CollapseBuilder {
+	collapse := elastic.NewCollapseBuilder(c.field)
+
+	if c.innerHits != nil {
+		collapse = collapse.InnerHit(c.innerHits.innerHit())
+	}
+
+	if c.maxConcurrentGroupSearches != nil {
+		collapse = collapse.MaxConcurrentGroupRequests(*c.maxConcurrentGroupSearches)
+	}
+
+	return collapse
+}
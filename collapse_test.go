@@ -0,0 +1,62 @@
+package golastic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollapseValidation(t *testing.T) {
+	builder := new(Builder)
+	builder.CollapseBy("family").MaxConcurrentGroupSearches(4).InnerHits("cheapest").Size(3).OrderBy("price", true)
+
+	if got := builder.collapse.validate(); got != nil {
+		t.Error("Expected no errors but got ", got)
+	}
+
+	builder = new(Builder)
+	builder.CollapseBy("family").InnerHits("").Size(3)
+
+	if got := builder.collapse.validate(); got == nil {
+		t.Error("Expected errors but got ", got)
+	}
+
+	builder = new(Builder)
+	builder.CollapseBy("family").MaxConcurrentGroupSearches(0)
+
+	if got := builder.collapse.validate(); got == nil {
+		t.Error("Expected errors but got ", got)
+	}
+}
+
+func TestNestedInnerHits(t *testing.T) {
+	builder := new(Builder)
+	builder.WhereNested("attributes.color", "=", "Red")
+	builder.NestedInnerHits("attributes").Name("red_attributes").Size(2)
+
+	if got := builder.validateNestedClauses(); got != nil {
+		t.Error("Expected no errors but got ", got)
+	}
+
+	source, err := builder.query().Source()
+
+	if err != nil {
+		t.Error("Expected no errors but got ", err)
+	}
+
+	container, err := toGabsContainer(source)
+
+	if err != nil {
+		t.Error("Expected no errors but got ", err)
+	}
+
+	assert.Equal(t, "red_attributes", container.Path("bool.must.nested.inner_hits.name").Data().(string))
+	assert.Equal(t, float64(2), container.Path("bool.must.nested.inner_hits.size").Data().(float64))
+
+	builder = new(Builder)
+	builder.NestedInnerHits("attributes")
+
+	if got := builder.validateNestedClauses(); got == nil {
+		t.Error("Expected errors but got ", got)
+	}
+}
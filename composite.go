@@ -0,0 +1,368 @@
+package golastic
+
+import (
+	"errors"
+
+	"github.com/Jeffail/gabs"
+	elastic "github.com/alejandro-carstens/elasticfork"
+)
+
+// Composite represents the sub-builder in charge of paginating
+// through every bucket of a composite aggregation
+type Composite struct {
+	name           string
+	size           int
+	sources        []*compositeSource
+	orders         []*sort
+	missingBuckets []string
+	metrics        []*Aggregation
+	builder        *Builder
+}
+
+type compositeSource struct {
+	Name     string
+	Type     string
+	Field    string
+	Interval interface{}
+}
+
+// Composite returns a composite aggregation sub-builder which retrieves size buckets per request for the
+// documents matching the query, the other aggregations, facets and min score of the builder are not sent
+func (b *Builder) Composite(name string, size int) *Composite {
+	return &Composite{name: name, size: size, builder: b}
+}
+
+// Terms adds a source which buckets the documents by the values of a field
+func (c *Composite) Terms(name string, field string) *Composite {
+	return c.source(name, "terms", field, nil)
+}
+
+// Histogram adds a source which buckets the numeric values of a field by the given interval
+func (c *Composite) Histogram(name string, field string, interval float64) *Composite {
+	return c.source(name, "histogram", field, interval)
+}
+
+// DateHistogram adds a source which buckets the values of a date field by the given interval, i.e. "1d"
+func (c *Composite) DateHistogram(name string, field string, interval string) *Composite {
+	return c.source(name, "date_histogram", field, interval)
+}
+
+// OrderBy sets the order of the values of the given source, which defaults to ascending
+func (c *Composite) OrderBy(name string, asc bool) *Composite {
+	c.orders = append(c.orders, &sort{Field: name, Order: asc})
+
+	return c
+}
+
+// MissingBucket includes a bucket for the documents lacking a value for the fields of the given sources
+func (c *Composite) MissingBucket(names ...string) *Composite {
+	c.missingBuckets = append(c.missingBuckets, names...)
+
+	return c
+}
+
+// Metric computes the given metric aggregation for each of the buckets
+func (c *Composite) Metric(name string, aggregation *Aggregation) *Composite {
+	aggregation.name = name
+
+	c.metrics = append(c.metrics, aggregation)
+
+	return c
+}
+
+// Page retrieves the buckets following the given after key, a nil
+// after key retrieves the first page of buckets
+func (c *Composite) Page(after map[string]interface{}) (*CompositeResponse, error) {
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+
+	source, err := c.builder.aggregationSource()
+
+	if err != nil {
+		return nil, err
+	}
+
+	source = source.Aggregation(c.name, c.aggregation(after))
+
+	response, err := c.builder.searchService().SearchSource(source).Do(c.builder.context)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Aggregations == nil {
+		return nil, errors.New("No aggregations returned")
+	}
+
+	raw, found := response.Aggregations[c.name]
+
+	if !found {
+		return nil, errors.New("No aggregations returned")
+	}
+
+	return c.parse(raw)
+}
+
+// Each follows the after key of every page until the buckets are exhausted,
+// feeding each page of buckets to the callback, an error returned by the callback stops the iteration
+func (c *Composite) Each(callback func(buckets []*CompositeBucket) error) error {
+	var after map[string]interface{}
+
+	for {
+		response, err := c.Page(after)
+
+		if err != nil {
+			return err
+		}
+
+		if len(response.Buckets) == 0 {
+			return nil
+		}
+
+		if err := callback(response.Buckets); err != nil {
+			return err
+		}
+
+		if len(response.AfterKey) == 0 {
+			return nil
+		}
+
+		after = response.AfterKey
+	}
+}
+
+func (c *Composite) source(name string, sourceType string, field string, interval interface{}) *Composite {
+	c.sources = append(c.sources, &compositeSource{
+		Name:     name,
+		Type:     sourceType,
+		Field:    field,
+		Interval: interval,
+	})
+
+	return c
+}
+
+func (c *Composite) validate() error {
+	if len(c.name) == 0 {
+		return errors.New("name cannot be empty")
+	}
+
+	if inSlice(c.name, c.builder.aggregationNames()...) {
+		return errors.New("The aggregation name " + c.name + " is already used by the builder.")
+	}
+
+	if c.size <= 0 {
+		return errors.New("The composite size needs to be greater than 0.")
+	}
+
+	if len(c.sources) == 0 {
+		return errors.New("Please specify at least a source.")
+	}
+
+	names := []string{}
+
+	for _, source := range c.sources {
+		if len(source.Name) == 0 {
+			return errors.New("name cannot be empty")
+		}
+
+		if len(source.Field) == 0 {
+			return errors.New("field cannot be empty")
+		}
+
+		if inSlice(source.Name, names...) {
+			return errors.New("The source name " + source.Name + " is duplicated.")
+		}
+
+		switch source.Type {
+		case "histogram":
+			if interval, _ := source.Interval.(float64); interval <= 0 {
+				return errors.New("The histogram interval needs to be greater than 0.")
+			}
+		case "date_histogram":
+			if interval, _ := source.Interval.(string); len(interval) == 0 {
+				return errors.New("interval cannot be empty")
+			}
+		}
+
+		names = append(names, source.Name)
+	}
+
+	for _, order := range c.orders {
+		if !inSlice(order.Field, names...) {
+			return errors.New("Cannot order by " + order.Field + " since it is not a source.")
+		}
+	}
+
+	for _, name := range c.missingBuckets {
+		if !inSlice(name, names...) {
+			return errors.New("Cannot include a missing bucket for " + name + " since it is not a source.")
+		}
+	}
+
+	names = []string{}
+
+	for _, metric := range c.metrics {
+		if metric.isBucketAggregation() {
+			return errors.New("The metric " + metric.name + " needs to be a metric aggregation.")
+		}
+
+		if inSlice(metric.name, names...) {
+			return errors.New("The metric name " + metric.name + " is duplicated.")
+		}
+
+		if err := metric.validate(); err != nil {
+			return err
+		}
+
+		names = append(names, metric.name)
+	}
+
+	return validatePipelines(c.metrics, nil)
+}
+
+func (c *Composite) aggregation(after map[string]interface{}) *elastic.CompositeAggregation {
+	aggregation := elastic.NewCompositeAggregation().Size(c.size)
+
+	for _, source := range c.sources {
+		aggregation = aggregation.Sources(source.valuesSource(c.order(source.Name), inSlice(source.Name, c.missingBuckets...)))
+	}
+
+	if after != nil {
+		aggregation = aggregation.AggregateAfter(after)
+	}
+
+	for _, metric := range c.metrics {
+		aggregation = aggregation.SubAggregation(metric.name, metric.aggregation())
+	}
+
+	return aggregation
+}
+
+// order returns the last order set for the given source, an empty order keeps the default
+func (c *Composite) order(name string) string {
+	order := ""
+
+	for _, sort := range c.orders {
+		if sort.Field != name {
+			continue
+		}
+
+		order = "desc"
+
+		if sort.Order {
+			order = "asc"
+		}
+	}
+
+	return order
+}
+
+// parse decodes the keys through json.Number so that large numeric
+// keys are neither rounded when returned nor when sent as the after key
+func (c *Composite) parse(raw []byte) (*CompositeResponse, error) {
+	container, err := gabs.ParseJSON(raw)
+
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := parseSource(raw)
+
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CompositeResponse{Buckets: []*CompositeBucket{}}
+
+	if afterKey, valid := keys.Path("after_key").Data().(map[string]interface{}); valid {
+		response.AfterKey = afterKey
+	}
+
+	if !container.Exists("buckets") {
+		return response, nil
+	}
+
+	buckets, err := container.Path("buckets").Children()
+
+	if err != nil {
+		return nil, err
+	}
+
+	bucketKeys, err := keys.Path("buckets").Children()
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i, bucket := range buckets {
+		key, _ := bucketKeys[i].Path("key").Data().(map[string]interface{})
+		docCount, _ := bucket.Path("doc_count").Data().(float64)
+
+		items := AggregationResponses{}
+
+		for _, metric := range c.metrics {
+			if !bucket.Exists(metric.name) {
+				continue
+			}
+
+			item, err := metric.parse(bucket.Search(metric.name))
+
+			if err != nil {
+				return nil, err
+			}
+
+			items[metric.name] = item
+		}
+
+		response.Buckets = append(response.Buckets, &CompositeBucket{
+			Key:      key,
+			DocCount: int(docCount),
+			Items:    items,
+		})
+	}
+
+	return response, nil
+}
+
+func (cs *compositeSource) valuesSource(order string, missingBucket bool) elastic.CompositeAggregationValuesSource {
+	switch cs.Type {
+	case "histogram":
+		source := elastic.NewCompositeAggregationHistogramValuesSource(cs.Name, cs.Interval.(float64)).Field(cs.Field)
+
+		if missingBucket {
+			source = source.MissingBucket(true)
+		}
+
+		if len(order) > 0 {
+			source = source.Order(order)
+		}
+
+		return source
+	case "date_histogram":
+		source := elastic.NewCompositeAggregationDateHistogramValuesSource(cs.Name, cs.Interval).Field(cs.Field)
+
+		if missingBucket {
+			source = source.MissingBucket(true)
+		}
+
+		if len(order) > 0 {
+			source = source.Order(order)
+		}
+
+		return source
+	}
+
+	source := elastic.NewCompositeAggregationTermsValuesSource(cs.Name).Field(cs.Field)
+
+	if missingBucket {
+		source = source.MissingBucket(true)
+	}
+
+	if len(order) > 0 {
+		source = source.Order(order)
+	}
+
+	return source
+}
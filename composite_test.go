@@ -0,0 +1,126 @@
+package golastic
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompositeValidation(t *testing.T) {
+	valid := new(Builder).Composite("customers", 100).
+		Terms("customer", "customer_id").
+		DateHistogram("day", "created_at", "1d").
+		Histogram("amount", "amount", 50).
+		Metric("total", SumAgg("amount"))
+
+	if got := valid.validate(); got != nil {
+		t.Error("Expected no errors but got ", got)
+	}
+
+	invalid := []*Composite{
+		new(Builder).Composite("", 100).Terms("customer", "customer_id"),
+		new(Builder).Composite("customers", 0).Terms("customer", "customer_id"),
+		new(Builder).Composite("customers", 100),
+		new(Builder).Composite("customers", 100).Terms("", "customer_id"),
+		new(Builder).Composite("customers", 100).Terms("customer", ""),
+		new(Builder).Composite("customers", 100).Terms("customer", "customer_id").Terms("customer", "email"),
+		new(Builder).Composite("customers", 100).Histogram("amount", "amount", 0),
+		new(Builder).Composite("customers", 100).DateHistogram("day", "created_at", ""),
+		new(Builder).Composite("customers", 100).Terms("customer", "customer_id").Metric("per_day", TermsAgg("day")),
+		new(Builder).Composite("customers", 100).OrderBy("customer", false).Terms("customer", "customer_id").OrderBy("email", true),
+		new(Builder).Composite("customers", 100).Terms("customer", "customer_id").MissingBucket("customer", "email"),
+	}
+
+	groupBy := new(Builder)
+	groupBy.GroupBy("category")
+
+	aggregation := new(Builder)
+	aggregation.Aggregation("customers", CardinalityAgg("customer_id"))
+
+	facet := new(Builder)
+	facet.Facet("brand")
+
+	invalid = append(
+		invalid,
+		groupBy.Composite("category", 100).Terms("customer", "customer_id"),
+		aggregation.Composite("customers", 100).Terms("customer", "customer_id"),
+		facet.Composite("facet_brand", 100).Terms("customer", "customer_id"),
+	)
+
+	for _, composite := range invalid {
+		if got := composite.validate(); got == nil {
+			t.Error("Expected errors but got ", got)
+		}
+	}
+}
+
+func TestCompositeSource(t *testing.T) {
+	composite := new(Builder).Composite("customers", 100).
+		MissingBucket("customer").
+		Terms("customer", "customer_id").
+		DateHistogram("day", "created_at", "1d").
+		OrderBy("day", false).
+		Metric("total", SumAgg("amount"))
+
+	source, err := composite.aggregation(map[string]interface{}{"customer": "c-1", "day": 1560000000000}).Source()
+
+	assert.Nil(t, err)
+
+	container, err := toGabsContainer(source)
+
+	assert.Nil(t, err)
+	assert.Equal(t, float64(100), container.Path("composite.size").Data())
+	assert.Equal(t, "c-1", container.Path("composite.after.customer").Data())
+
+	sources, err := container.Path("composite.sources").Children()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "customer_id", sources[0].Path("customer.terms.field").Data())
+	assert.Equal(t, true, sources[0].Path("customer.terms.missing_bucket").Data())
+	assert.Equal(t, "desc", sources[1].Path("day.date_histogram.order").Data())
+	assert.False(t, sources[1].Exists("day", "date_histogram", "missing_bucket"))
+	assert.Equal(t, "amount", container.Path("aggregations.total.sum.field").Data())
+}
+
+func TestCompositeParsing(t *testing.T) {
+	composite := new(Builder).Composite("customers", 2).
+		Terms("customer", "customer_id").
+		Metric("total", SumAgg("amount")).
+		Metric("top", BucketSortAgg().OrderBy("total", false))
+
+	response, err := composite.parse([]byte(`{
+		"after_key": {"customer": "c-2"},
+		"buckets": [
+			{"key": {"customer": "c-1"}, "doc_count": 3, "total": {"value": 30}},
+			{"key": {"customer": "c-2"}, "doc_count": 1, "total": {"value": 12.5}}
+		]
+	}`))
+
+	assert.Nil(t, err)
+	assert.Equal(t, "c-2", response.AfterKey["customer"])
+	assert.Equal(t, 2, len(response.Buckets))
+	assert.Equal(t, "c-1", response.Buckets[0].Key["customer"])
+	assert.Equal(t, 3, response.Buckets[0].DocCount)
+	assert.Equal(t, 12.5, *response.Buckets[1].Items["total"].Value)
+	assert.Nil(t, response.Buckets[1].Items["top"])
+
+	ids := new(Builder).Composite("ids", 2).Terms("id", "id")
+
+	response, err = ids.parse([]byte(`{
+		"after_key": {"id": 9007199254740993},
+		"buckets": [{"key": {"id": 9007199254740993}, "doc_count": 1}]
+	}`))
+
+	assert.Nil(t, err)
+	assert.Equal(t, json.Number("9007199254740993"), response.Buckets[0].Key["id"])
+
+	source, err := ids.aggregation(response.AfterKey).Source()
+
+	assert.Nil(t, err)
+
+	data, err := json.Marshal(source)
+
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), `"after":{"id":9007199254740993}`)
+}
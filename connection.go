@@ -56,11 +56,32 @@ func (c *Connection) Indexer(options *IndexOptions) *Indexer {
 	}
 }
 
-// Builder creates a new Builder
-func (c *Connection) Builder(index string) *Builder {
+// Builder creates a new Builder, documents are written to and retrieved by id from the given index while
+// queries also target the additional indices. Wildcard patterns, aliases and date math names are supported
+func (c *Connection) Builder(index string, indices ...string) *Builder {
 	return &Builder{
 		client:  c.client,
 		index:   index,
+		indices: indices,
 		context: c.context.Context,
 	}
 }
+
+// Percolator creates a new Percolator for the given percolator index
+func (c *Connection) Percolator(index string) *Percolator {
+	return &Percolator{
+		client:  c.client,
+		index:   index,
+		field:   PERCOLATOR_FIELD,
+		context: c.context.Context,
+	}
+}
+
+// MultiSearch creates a new MultiSearch for executing the given builders in a single request
+func (c *Connection) MultiSearch(builders ...*Builder) *MultiSearch {
+	return &MultiSearch{
+		builders: builders,
+		client:   c.client,
+		context:  c.context.Context,
+	}
+}
@@ -3,9 +3,18 @@ package golastic
 // CONCURRENT_BATCH is the default concurrent response proccesing batch size
 const CONCURRENT_BATCH int = 10
 
+// DISTINCT_PAGE_SIZE is the number of distinct values retrieved per request by Distinct
+const DISTINCT_PAGE_SIZE int = 1000
+
 // LIMIT is the default limit of documents to be returned by elasticsearch
 const LIMIT int = 10000
 
+// PERCOLATOR_FIELD is the default field percolator queries are stored in
+const PERCOLATOR_FIELD string = "query"
+
+// REVERSE_NESTED is the name of the reverse_nested aggregation counting the parent documents of a nested bucket
+const REVERSE_NESTED string = "reverse_nested"
+
 // VALUE_AS_STRING self explanatory
 const VALUE_AS_STRING string = "value_as_string"
 
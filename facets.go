@@ -0,0 +1,170 @@
+package golastic
+
+import (
+	"errors"
+
+	"github.com/Jeffail/gabs"
+	elastic "github.com/alejandro-carstens/elasticfork"
+)
+
+// Facet represents the struct in charge of configuring a facet, the selected values of every facet filter
+// the hits while the counts of each facet only take into account the selections of the other facets
+type Facet struct {
+	field    string
+	selected []interface{}
+	size     *int
+}
+
+// Facet adds a facet counting the hits for each value of the given field, the hits
+// are filtered by the selected values through a post_filter and searched through Search
+func (b *Builder) Facet(field string, selected ...interface{}) *Facet {
+	facet := &Facet{field: field, selected: selected}
+
+	b.facets = append(b.facets, facet)
+
+	return facet
+}
+
+// Size sets the number of values to be returned for the facet
+func (f *Facet) Size(size int) *Facet {
+	f.size = &size
+
+	return f
+}
+
+func (f *Facet) validate() error {
+	if len(f.field) == 0 {
+		return errors.New("field cannot be empty")
+	}
+
+	if f.size != nil && *f.size <= 0 {
+		return errors.New("The facet size needs to be greater than 0.")
+	}
+
+	return nil
+}
+
+func (f *Facet) name() string {
+	return "facet_" + f.field
+}
+
+// aggregation counts the values of the facet for the hits matching the selections of the other facets
+func (f *Facet) aggregation(facets []*Facet) *Aggregation {
+	values := TermsAgg(f.field)
+
+	if f.size != nil {
+		values.Size(*f.size)
+	}
+
+	aggregation := FilterAgg(func(builder *Builder) {
+		for _, facet := range facets {
+			if facet != f && len(facet.selected) > 0 {
+				builder.FilterIn(facet.field, facet.selected)
+			}
+		}
+	}).SubAggregation("values", values)
+	aggregation.name = f.name()
+
+	return aggregation
+}
+
+func (f *Facet) parse(aggregation *Aggregation, container *gabs.Container) (*FacetResponse, error) {
+	response, err := aggregation.parse(container)
+
+	if err != nil {
+		return nil, err
+	}
+
+	facet := &FacetResponse{Field: f.field, Values: []*FacetValue{}}
+	found := []interface{}{}
+
+	if values, valid := response.Items["values"]; valid {
+		for _, bucket := range values.Buckets {
+			facet.Values = append(facet.Values, &FacetValue{
+				Value:    bucket.Key,
+				Count:    bucket.DocCount,
+				Selected: f.isSelected(bucket.Key),
+			})
+
+			found = append(found, bucket.Key)
+		}
+	}
+
+	for _, value := range f.selected {
+		if !containsValue(found, value) {
+			facet.Values = append(facet.Values, &FacetValue{Value: value, Selected: true})
+		}
+	}
+
+	return facet, nil
+}
+
+func (f *Facet) isSelected(value interface{}) bool {
+	return containsValue(f.selected, value)
+}
+
+func (b *Builder) postFilter() elastic.Query {
+	builder := new(Builder)
+
+	for _, facet := range b.facets {
+		if len(facet.selected) > 0 {
+			builder.FilterIn(facet.field, facet.selected)
+		}
+	}
+
+	if len(builder.filterIns) == 0 {
+		return nil
+	}
+
+	return builder.query()
+}
+
+func (b *Builder) findFacet(name string) *Facet {
+	for _, facet := range b.facets {
+		if facet.name() == name {
+			return facet
+		}
+	}
+
+	return nil
+}
+
+func (b *Builder) processFacets(aggregations elastic.Aggregations) ([]*FacetResponse, error) {
+	facets := []*FacetResponse{}
+
+	for _, facet := range b.facets {
+		raw, found := aggregations[facet.name()]
+
+		if !found {
+			return nil, errors.New("No aggregations returned for facet " + facet.field)
+		}
+
+		container, err := gabs.ParseJSON(raw)
+
+		if err != nil {
+			return nil, err
+		}
+
+		response, err := facet.parse(facet.aggregation(b.facets), container)
+
+		if err != nil {
+			return nil, err
+		}
+
+		facets = append(facets, response)
+	}
+
+	return facets, nil
+}
+
+// containsValue compares the values through their string representation since
+// the keys returned by elasticsearch are decoded as strings or float64
+func containsValue(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if formatCell(v) == formatCell(value) {
+			return true
+		}
+	}
+
+	return false
+}
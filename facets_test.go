@@ -0,0 +1,135 @@
+package golastic
+
+import (
+	"encoding/json"
+	"testing"
+
+	elastic "github.com/alejandro-carstens/elasticfork"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFacetValidation(t *testing.T) {
+	valid := new(Builder)
+	valid.Facet("brand", "acme").Size(20)
+	valid.Facet("color")
+
+	if _, got := valid.searchSource(); got != nil {
+		t.Error("Expected no errors but got ", got)
+	}
+
+	emptyField := new(Builder)
+	emptyField.Facet("")
+
+	invalidSize := new(Builder)
+	invalidSize.Facet("brand").Size(0)
+
+	duplicated := new(Builder)
+	duplicated.Facet("brand", "acme")
+	duplicated.Facet("brand", "zeta")
+
+	aggregation := new(Builder)
+	aggregation.Facet("brand")
+	aggregation.Aggregation("facet_brand", CardinalityAgg("brand"))
+
+	groupBy := new(Builder)
+	groupBy.GroupBy("facet_color")
+	groupBy.Facet("color")
+
+	stats := new(Builder)
+	stats.Stats("facet_size")
+	stats.Facet("size")
+
+	for _, builder := range []*Builder{emptyField, invalidSize, duplicated, aggregation, groupBy, stats} {
+		if _, got := builder.searchSource(); got == nil {
+			t.Error("Expected errors but got ", got)
+		}
+	}
+}
+
+func TestFacetSource(t *testing.T) {
+	builder := new(Builder)
+	builder.Where("category", "=", "shoes")
+	builder.Facet("brand", "acme", "zeta").Size(5)
+	builder.Facet("color", "red")
+	builder.Facet("size")
+
+	source, err := builder.searchSource()
+
+	assert.Nil(t, err)
+
+	data, err := source.Source()
+
+	assert.Nil(t, err)
+
+	container, err := toGabsContainer(data)
+
+	assert.Nil(t, err)
+
+	postFilter, err := container.Path("post_filter.bool.filter").Children()
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(postFilter))
+	assert.Equal(t, []interface{}{"acme", "zeta"}, postFilter[0].Path("terms.brand").Data())
+	assert.Equal(t, []interface{}{"red"}, postFilter[1].Path("terms.color").Data())
+	assert.True(t, container.Exists("query", "bool"))
+
+	brand := container.Search("aggregations", "facet_brand")
+
+	assert.Equal(t, "brand", brand.Path("aggregations.values.terms.field").Data())
+	assert.Equal(t, float64(5), brand.Path("aggregations.values.terms.size").Data())
+	assert.Equal(t, []interface{}{"red"}, brand.Path("filter.bool.filter.terms.color").Data())
+	assert.False(t, brand.Exists("filter", "bool", "filter", "terms", "brand"))
+
+	color := container.Search("aggregations", "facet_color")
+
+	assert.Equal(t, []interface{}{"acme", "zeta"}, color.Path("filter.bool.filter.terms.brand").Data())
+
+	size, err := container.Search("aggregations", "facet_size", "filter", "bool", "filter").Children()
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(size))
+}
+
+func TestFacetParsing(t *testing.T) {
+	builder := new(Builder)
+	builder.Facet("brand", "acme", "nobody")
+	builder.Facet("rating", 5)
+	builder.Aggregation("avg_price", AvgAgg("price"))
+
+	raw := `{
+		"facet_brand": {"doc_count": 10, "values": {"buckets": [
+			{"key": "zeta", "doc_count": 6},
+			{"key": "acme", "doc_count": 4}
+		]}},
+		"facet_rating": {"doc_count": 3, "values": {"buckets": [
+			{"key": 5, "doc_count": 2},
+			{"key": 4, "doc_count": 1}
+		]}},
+		"avg_price": {"value": 25}
+	}`
+
+	aggregations := elastic.Aggregations{}
+
+	assert.Nil(t, json.Unmarshal([]byte(raw), &aggregations))
+
+	facets, err := builder.processFacets(aggregations)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(facets))
+	assert.Equal(t, "brand", facets[0].Field)
+	assert.Equal(t, []*FacetValue{
+		{Value: "zeta", Count: 6},
+		{Value: "acme", Count: 4, Selected: true},
+		{Value: "nobody", Selected: true},
+	}, facets[0].Values)
+	assert.Equal(t, []*FacetValue{
+		{Value: float64(5), Count: 2, Selected: true},
+		{Value: float64(4), Count: 1},
+	}, facets[1].Values)
+
+	response, err := builder.processAggregations(aggregations)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(response))
+	assert.Equal(t, float64(25), *response["avg_price"].Value)
+}
@@ -0,0 +1,388 @@
+package golastic
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/Jeffail/gabs"
+	elastic "github.com/alejandro-carstens/elasticfork"
+)
+
+// GroupByField represents the sub-builder in charge of tuning the terms aggregation of a GroupBy field
+type GroupByField struct {
+	field         string
+	size          *int
+	shardSize     *int
+	minDocCount   *int
+	missing       interface{}
+	include       string
+	exclude       string
+	includeValues []interface{}
+	excludeValues []interface{}
+	sorts         []*sort
+	metrics       []*Aggregation
+	reverseNested bool
+	path          *string
+}
+
+// GroupByField returns the sub-builder for setting the options of the given GroupBy field
+func (b *Builder) GroupByField(field string) *GroupByField {
+	if b.groupByFields == nil {
+		b.groupByFields = map[string]*GroupByField{}
+	}
+
+	if _, exists := b.groupByFields[field]; !exists {
+		b.groupByFields[field] = &GroupByField{field: field}
+	}
+
+	return b.groupByFields[field]
+}
+
+// Size sets the number of buckets to be returned, which defaults to 10
+func (gbf *GroupByField) Size(size int) *GroupByField {
+	gbf.size = &size
+
+	return gbf
+}
+
+// ShardSize sets the number of buckets each shard returns for improving the accuracy of the counts
+func (gbf *GroupByField) ShardSize(shardSize int) *GroupByField {
+	gbf.shardSize = &shardSize
+
+	return gbf
+}
+
+// MinDocCount sets the minimum number of documents a bucket needs in order to be returned
+func (gbf *GroupByField) MinDocCount(minDocCount int) *GroupByField {
+	gbf.minDocCount = &minDocCount
+
+	return gbf
+}
+
+// Missing groups the documents lacking a value for the field under the given value
+func (gbf *GroupByField) Missing(value interface{}) *GroupByField {
+	gbf.missing = value
+
+	return gbf
+}
+
+// Include restricts the buckets to the values matching the given regular expression
+func (gbf *GroupByField) Include(pattern string) *GroupByField {
+	gbf.include = pattern
+
+	return gbf
+}
+
+// Exclude removes the buckets whose values match the given regular expression
+func (gbf *GroupByField) Exclude(pattern string) *GroupByField {
+	gbf.exclude = pattern
+
+	return gbf
+}
+
+// IncludeValues restricts the buckets to the given values
+func (gbf *GroupByField) IncludeValues(values ...interface{}) *GroupByField {
+	gbf.includeValues = append(gbf.includeValues, values...)
+
+	return gbf
+}
+
+// ExcludeValues removes the buckets of the given values
+func (gbf *GroupByField) ExcludeValues(values ...interface{}) *GroupByField {
+	gbf.excludeValues = append(gbf.excludeValues, values...)
+
+	return gbf
+}
+
+// OrderBy sorts the buckets by "_key", "_count" or a metric name, multi value metrics
+// can be referenced through the dot notation, i.e. "price_stats.avg"
+func (gbf *GroupByField) OrderBy(field string, asc bool) *GroupByField {
+	gbf.sorts = append(gbf.sorts, &sort{Field: field, Order: asc})
+
+	return gbf
+}
+
+// Metric computes the given metric aggregation for each of the buckets, i.e. the average price per category
+func (gbf *GroupByField) Metric(name string, aggregation *Aggregation) *GroupByField {
+	aggregation.name = name
+
+	gbf.metrics = append(gbf.metrics, aggregation)
+
+	return gbf
+}
+
+// ReverseNested counts the parent documents of each bucket of a nested field, which are
+// reported as the ParentDocCount of the bucket instead of the count of nested objects
+func (gbf *GroupByField) ReverseNested() *GroupByField {
+	gbf.reverseNested = true
+
+	return gbf
+}
+
+// Nested overrides the nested path guessed from the object.property notation of the field, i.e. "cast.roles"
+// for "cast.roles.name", an empty path aggregates multi-fields such as "category.keyword" on the root documents
+func (gbf *GroupByField) Nested(path string) *GroupByField {
+	gbf.path = &path
+
+	return gbf
+}
+
+func (gbf *GroupByField) validate(fields []string) error {
+	if !inSlice(gbf.field, fields...) {
+		return errors.New("The field " + gbf.field + " is not part of the GroupBy clause.")
+	}
+
+	if gbf.size != nil && *gbf.size <= 0 {
+		return errors.New("The size needs to be greater than 0.")
+	}
+
+	if gbf.shardSize != nil && *gbf.shardSize <= 0 {
+		return errors.New("The shard size needs to be greater than 0.")
+	}
+
+	if err := validateNestedPath(gbf.field, gbf.path); err != nil {
+		return err
+	}
+
+	if gbf.minDocCount != nil && *gbf.minDocCount < 0 {
+		return errors.New("The min doc count cannot be negative.")
+	}
+
+	if len(gbf.include) > 0 && len(gbf.includeValues) > 0 {
+		return errors.New("Include and IncludeValues cannot be used together.")
+	}
+
+	if len(gbf.exclude) > 0 && len(gbf.excludeValues) > 0 {
+		return errors.New("Exclude and ExcludeValues cannot be used together.")
+	}
+
+	names := []string{}
+
+	for _, metric := range gbf.metrics {
+		if metric.isBucketAggregation() {
+			return errors.New("The metric " + metric.name + " needs to be a metric aggregation.")
+		}
+
+		if inSlice(metric.name, names...) || inSlice(metric.name, fields...) || (gbf.reverseNested && metric.name == REVERSE_NESTED) {
+			return errors.New("The metric name " + metric.name + " is duplicated.")
+		}
+
+		if err := metric.validate(); err != nil {
+			return err
+		}
+
+		names = append(names, metric.name)
+	}
+
+	for _, sort := range gbf.sorts {
+		if inSlice(sort.Field, "_key", "_count") {
+			continue
+		}
+
+		if !inSlice(strings.Split(sort.Field, ".")[0], names...) {
+			return errors.New("The buckets can only be ordered by _key, _count or one of the metrics.")
+		}
+	}
+
+	return validatePipelines(gbf.metrics, TermsAgg(gbf.field))
+}
+
+func (gbf *GroupByField) aggregation(aggregation *elastic.TermsAggregation) *elastic.TermsAggregation {
+	if gbf.size != nil {
+		aggregation = aggregation.Size(*gbf.size)
+	}
+
+	if gbf.shardSize != nil {
+		aggregation = aggregation.ShardSize(*gbf.shardSize)
+	}
+
+	if gbf.minDocCount != nil {
+		aggregation = aggregation.MinDocCount(*gbf.minDocCount)
+	}
+
+	if gbf.missing != nil {
+		aggregation = aggregation.Missing(gbf.missing)
+	}
+
+	if len(gbf.include) > 0 {
+		aggregation = aggregation.Include(gbf.include)
+	}
+
+	if len(gbf.exclude) > 0 {
+		aggregation = aggregation.Exclude(gbf.exclude)
+	}
+
+	if len(gbf.includeValues) > 0 {
+		aggregation = aggregation.IncludeValues(gbf.includeValues...)
+	}
+
+	if len(gbf.excludeValues) > 0 {
+		aggregation = aggregation.ExcludeValues(gbf.excludeValues...)
+	}
+
+	for _, sort := range gbf.sorts {
+		aggregation = aggregation.Order(sort.Field, sort.Order)
+	}
+
+	for _, metric := range gbf.metrics {
+		aggregation = aggregation.SubAggregation(metric.name, metric.aggregation())
+	}
+
+	if gbf.reverseNested {
+		aggregation = aggregation.SubAggregation(REVERSE_NESTED, elastic.NewReverseNestedAggregation())
+	}
+
+	return aggregation
+}
+
+func (b *Builder) groupByAggregation(field string) *elastic.TermsAggregation {
+	aggregation := elastic.NewTermsAggregation().Field(field)
+
+	if options, exists := b.groupByFields[field]; exists {
+		return options.aggregation(aggregation)
+	}
+
+	return aggregation
+}
+
+func (b *Builder) groupByPath(field string) string {
+	if options, exists := b.groupByFields[field]; exists && options.path != nil {
+		return *options.path
+	}
+
+	return nestedPath(field)
+}
+
+// nestedGroupBy wraps the aggregation of a field in the nested or reverse_nested aggregations
+// needed for moving from the nested path of the parent aggregation to the path of the field
+func (b *Builder) nestedGroupBy(field string, aggregation elastic.Aggregation, parentPath string) elastic.Aggregation {
+	path := b.groupByPath(field)
+
+	switch {
+	case path == parentPath:
+		return aggregation
+	case len(path) == 0:
+		return elastic.NewReverseNestedAggregation().SubAggregation(field, aggregation)
+	case len(parentPath) == 0 || isWithinPath(path, parentPath):
+		return elastic.NewNestedAggregation().Path(path).SubAggregation(field, aggregation)
+	case isWithinPath(parentPath, path):
+		return elastic.NewReverseNestedAggregation().Path(path).SubAggregation(field, aggregation)
+	}
+
+	nested := elastic.NewNestedAggregation().Path(path).SubAggregation(field, aggregation)
+
+	return elastic.NewReverseNestedAggregation().SubAggregation(field, nested)
+}
+
+// unwrapNestedGroupBy is the counterpart of nestedGroupBy for the aggregation responses
+func (b *Builder) unwrapNestedGroupBy(field string, container *gabs.Container, parentPath string) *gabs.Container {
+	path := b.groupByPath(field)
+
+	if path == parentPath {
+		return container
+	}
+
+	if len(path) > 0 && len(parentPath) > 0 && !isWithinPath(path, parentPath) && !isWithinPath(parentPath, path) {
+		return container.Search(field, field)
+	}
+
+	return container.Search(field)
+}
+
+// isWithinPath reports whether the field or nested path belongs to the given nested path
+func isWithinPath(field string, path string) bool {
+	return strings.HasPrefix(field, path+".")
+}
+
+// nestedPath returns the nested path of a field using the object.property notation of the nested clauses
+func nestedPath(field string) string {
+	if !strings.Contains(field, ".") {
+		return ""
+	}
+
+	return strings.Split(field, ".")[0]
+}
+
+func validateNestedPath(field string, path *string) error {
+	if path != nil && len(*path) > 0 && !isWithinPath(field, *path) {
+		return errors.New("The field " + field + " is not within the nested path " + *path + ".")
+	}
+
+	return nil
+}
+
+func (b *Builder) parseGroupBy(field string, container *gabs.Container, children []string) (*AggregationResponse, error) {
+	containers, err := container.Path("buckets").Children()
+
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := []*Aggregation{}
+	reverseNested := false
+
+	if options, exists := b.groupByFields[field]; exists {
+		metrics = options.metrics
+		reverseNested = options.reverseNested
+	}
+
+	buckets := aggregationBuckets{}
+
+	for _, bucket := range containers {
+		var items AggregationResponses
+
+		if children != nil || len(metrics) > 0 {
+			items = AggregationResponses{}
+		}
+
+		for _, child := range children {
+			item, err := b.parseGroupBy(child, b.unwrapNestedGroupBy(child, bucket.Search(child), b.groupByPath(field)), nil)
+
+			if err != nil {
+				return nil, err
+			}
+
+			items[child] = item
+		}
+
+		for _, metric := range metrics {
+			if !bucket.Exists(metric.name) {
+				continue
+			}
+
+			item, err := metric.parse(bucket.Search(metric.name))
+
+			if err != nil {
+				return nil, err
+			}
+
+			items[metric.name] = item
+		}
+
+		docCount, _ := bucket.Path("doc_count").Data().(float64)
+
+		aggregationBucket := &AggregationBucket{
+			DocCount: int(docCount),
+			Items:    items,
+			Key:      bucket.Path("key").Data(),
+		}
+
+		if reverseNested {
+			parentDocCount, _ := bucket.Search(REVERSE_NESTED, "doc_count").Data().(float64)
+			count := int(parentDocCount)
+
+			aggregationBucket.ParentDocCount = &count
+		}
+
+		buckets = append(buckets, aggregationBucket)
+	}
+
+	docCountErrorUpperBound, _ := container.Path("doc_count_error_upper_bound").Data().(float64)
+	sumOtherDocCount, _ := container.Path("sum_other_doc_count").Data().(float64)
+
+	return &AggregationResponse{
+		DocCountErrorUpperBound: int(docCountErrorUpperBound),
+		SumOtherDocCount:        int(sumOtherDocCount),
+		Buckets:                 buckets,
+	}, nil
+}
@@ -0,0 +1,289 @@
+package golastic
+
+import (
+	"encoding/json"
+	"testing"
+
+	elastic "github.com/alejandro-carstens/elasticfork"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupByFieldValidation(t *testing.T) {
+	fields := []string{"category", "brand"}
+
+	valid := []*GroupByField{
+		new(Builder).GroupByField("category").Size(50).ShardSize(100).MinDocCount(0).Missing("N/A"),
+		new(Builder).GroupByField("brand").Include("a.*").ExcludeValues("acme"),
+		new(Builder).GroupByField("category").Metric("avg_price", AvgAgg("price")).OrderBy("avg_price", false),
+		new(Builder).GroupByField("category").Metric("price", StatsAgg("price")).OrderBy("price.max", true).OrderBy("_key", true),
+	}
+
+	for _, options := range valid {
+		if got := options.validate(fields); got != nil {
+			t.Error("Expected no errors but got ", got)
+		}
+	}
+
+	invalid := []*GroupByField{
+		new(Builder).GroupByField("price"),
+		new(Builder).GroupByField("category").Size(0),
+		new(Builder).GroupByField("category").ShardSize(-1),
+		new(Builder).GroupByField("category").MinDocCount(-1),
+		new(Builder).GroupByField("category").Include("a.*").IncludeValues("acme"),
+		new(Builder).GroupByField("category").Exclude("a.*").ExcludeValues("acme"),
+		new(Builder).GroupByField("category").Metric("per_day", DateHistogramAgg("created_at", "day")),
+		new(Builder).GroupByField("category").Metric("brand", AvgAgg("price")),
+		new(Builder).GroupByField("category").Metric("price", AvgAgg("price")).Metric("price", MaxAgg("price")),
+		new(Builder).GroupByField("category").OrderBy("avg_price", false),
+	}
+
+	for _, options := range invalid {
+		if got := options.validate(fields); got == nil {
+			t.Error("Expected errors but got ", got)
+		}
+	}
+
+	builder := new(Builder)
+	builder.GroupByField("category").Size(-1)
+
+	if _, got := builder.searchSource(); got == nil {
+		t.Error("Expected errors but got ", got)
+	}
+}
+
+func TestGroupByFieldSource(t *testing.T) {
+	builder := new(Builder)
+	builder.GroupBy("category", "brand")
+	builder.GroupByField("category").
+		Size(50).
+		ShardSize(100).
+		Missing("N/A").
+		Metric("avg_price", AvgAgg("price")).
+		OrderBy("avg_price", false)
+	builder.GroupByField("brand").MinDocCount(2).Exclude("generic.*")
+
+	source, err := builder.searchSource()
+
+	assert.Nil(t, err)
+
+	data, err := source.Source()
+
+	assert.Nil(t, err)
+
+	container, err := toGabsContainer(data)
+
+	assert.Nil(t, err)
+	assert.Equal(t, float64(50), container.Path("aggregations.category.terms.size").Data())
+	assert.Equal(t, float64(100), container.Path("aggregations.category.terms.shard_size").Data())
+	assert.Equal(t, "N/A", container.Path("aggregations.category.terms.missing").Data())
+	assert.Equal(t, []interface{}{"desc"}, container.Path("aggregations.category.terms.order.avg_price").Data())
+	assert.Equal(t, "price", container.Path("aggregations.category.aggregations.avg_price.avg.field").Data())
+	assert.Equal(t, float64(2), container.Path("aggregations.category.aggregations.brand.terms.min_doc_count").Data())
+	assert.Equal(t, "generic.*", container.Path("aggregations.category.aggregations.brand.terms.exclude").Data())
+}
+
+func TestGroupByFieldParsing(t *testing.T) {
+	builder := new(Builder)
+	builder.GroupBy("category", "brand")
+	builder.GroupByField("category").
+		Metric("avg_price", AvgAgg("price")).
+		Metric("expensive", BucketSelectorAgg("params.avg > 10", map[string]string{"avg": "avg_price"}))
+	builder.GroupByField("brand").
+		Metric("max_price", MaxAgg("price")).
+		Metric("top", BucketSortAgg().OrderBy("max_price", false).Size(5))
+
+	if _, err := builder.searchSource(); err != nil {
+		t.Error("Expected no errors but got ", err)
+	}
+
+	raw := `{
+		"category": {
+			"buckets": [{
+				"key": "shoes",
+				"doc_count": 3,
+				"avg_price": {"value": 50},
+				"brand": {"buckets": [{"key": "acme", "doc_count": 3, "max_price": {"value": 80}}]}
+			}]
+		}
+	}`
+
+	aggregations := elastic.Aggregations{}
+
+	assert.Nil(t, json.Unmarshal([]byte(raw), &aggregations))
+
+	response, err := builder.processAggregations(aggregations)
+
+	assert.Nil(t, err)
+
+	category := response["category"].Buckets[0]
+
+	assert.Equal(t, "shoes", category.Key)
+	assert.Equal(t, float64(50), *category.Items["avg_price"].Value)
+	assert.Equal(t, "acme", category.Items["brand"].Buckets[0].Key)
+	assert.Equal(t, float64(80), *category.Items["brand"].Buckets[0].Items["max_price"].Value)
+	assert.Nil(t, category.Items["expensive"])
+	assert.Nil(t, category.Items["brand"].Buckets[0].Items["top"])
+}
+
+func TestGroupByNested(t *testing.T) {
+	builder := new(Builder)
+	builder.GroupBy("cast.director", "rating", "cast.actor")
+	builder.GroupByField("cast.director").ReverseNested()
+	builder.Stats("views", "cast.age")
+
+	source, err := builder.searchSource()
+
+	assert.Nil(t, err)
+
+	data, err := source.Source()
+
+	assert.Nil(t, err)
+
+	container, err := toGabsContainer(data)
+
+	assert.Nil(t, err)
+
+	director := container.Search("aggregations", "cast.director")
+
+	assert.Equal(t, "cast", director.Search("nested", "path").Data())
+	assert.Equal(t, "cast.director", director.Search("aggregations", "cast.director", "terms", "field").Data())
+
+	subAggregations := director.Search("aggregations", "cast.director", "aggregations")
+
+	assert.True(t, subAggregations.Exists("reverse_nested", "reverse_nested"))
+	assert.Equal(t, "rating", subAggregations.Search("rating", "aggregations", "rating", "terms", "field").Data())
+	assert.True(t, subAggregations.Exists("rating", "reverse_nested"))
+	assert.Equal(t, "cast.actor", subAggregations.Search("cast.actor", "terms", "field").Data())
+	assert.Equal(t, "views", container.Search("aggregations", "views", "extended_stats", "field").Data())
+	assert.Equal(t, "cast", container.Search("aggregations", "cast.age", "nested", "path").Data())
+
+	raw := `{
+		"cast.director": {
+			"doc_count": 10,
+			"cast.director": {
+				"buckets": [{
+					"key": "James Cameron",
+					"doc_count": 4,
+					"reverse_nested": {"doc_count": 3},
+					"rating": {"doc_count": 3, "rating": {"buckets": [{"key": "R", "doc_count": 3}]}},
+					"cast.actor": {"buckets": [{"key": "Sam Worthington", "doc_count": 2}]}
+				}]
+			}
+		},
+		"views": {"count": 3, "min": 1, "max": 3, "avg": 2, "sum": 6},
+		"cast.age": {"doc_count": 10, "cast.age": {"count": 10, "min": 20, "max": 60, "avg": 40, "sum": 400}}
+	}`
+
+	aggregations := elastic.Aggregations{}
+
+	assert.Nil(t, json.Unmarshal([]byte(raw), &aggregations))
+
+	response, err := builder.processAggregations(aggregations)
+
+	assert.Nil(t, err)
+
+	bucket := response["cast.director"].Buckets[0]
+
+	assert.Equal(t, "James Cameron", bucket.Key)
+	assert.Equal(t, 4, bucket.DocCount)
+	assert.Equal(t, 3, *bucket.ParentDocCount)
+	assert.Equal(t, "R", bucket.Items["rating"].Buckets[0].Key)
+	assert.Equal(t, "Sam Worthington", bucket.Items["cast.actor"].Buckets[0].Key)
+	assert.Equal(t, float64(2), *response["views"].Stats.Avg)
+	assert.Equal(t, float64(40), *response["cast.age"].Stats.Avg)
+}
+
+func TestGroupByNestedPaths(t *testing.T) {
+	builder := new(Builder)
+	builder.GroupBy("cast.roles.name", "cast.name", "studio.name", "category.keyword")
+	builder.GroupByField("cast.roles.name").Nested("cast.roles")
+	builder.GroupByField("category.keyword").Nested("")
+
+	source, err := builder.searchSource()
+
+	assert.Nil(t, err)
+
+	data, err := source.Source()
+
+	assert.Nil(t, err)
+
+	container, err := toGabsContainer(data)
+
+	assert.Nil(t, err)
+
+	roles := container.Search("aggregations", "cast.roles.name")
+
+	assert.Equal(t, "cast.roles", roles.Search("nested", "path").Data())
+
+	subAggregations := roles.Search("aggregations", "cast.roles.name", "aggregations")
+
+	assert.Equal(t, "cast", subAggregations.Search("cast.name", "reverse_nested", "path").Data())
+	assert.Equal(t, "studio", subAggregations.Search("studio.name", "aggregations", "studio.name", "nested", "path").Data())
+	assert.True(t, subAggregations.Exists("studio.name", "reverse_nested"))
+	assert.Equal(t, "category.keyword", subAggregations.Search("category.keyword", "aggregations", "category.keyword", "terms", "field").Data())
+
+	root := new(Builder)
+	root.GroupBy("category.keyword", "cast.name")
+	root.GroupByField("category.keyword").Nested("")
+	root.Stats("price.scaled")
+	root.StatsField("price.scaled").Nested("")
+
+	source, err = root.searchSource()
+
+	assert.Nil(t, err)
+
+	data, err = source.Source()
+
+	assert.Nil(t, err)
+
+	container, err = toGabsContainer(data)
+
+	assert.Nil(t, err)
+
+	category := container.Search("aggregations", "category.keyword")
+
+	assert.Equal(t, "category.keyword", category.Search("terms", "field").Data())
+	assert.Equal(t, "cast", category.Search("aggregations", "cast.name", "nested", "path").Data())
+	assert.Equal(t, "price.scaled", container.Search("aggregations", "price.scaled", "extended_stats", "field").Data())
+
+	raw := `{
+		"cast.roles.name": {
+			"doc_count": 4,
+			"cast.roles.name": {"buckets": [{
+				"key": "Jake Sully",
+				"doc_count": 2,
+				"cast.name": {"doc_count": 2, "cast.name": {"buckets": [{"key": "Sam Worthington", "doc_count": 2}]}},
+				"studio.name": {"doc_count": 1, "studio.name": {"doc_count": 1, "studio.name": {"buckets": [{"key": "Fox", "doc_count": 1}]}}},
+				"category.keyword": {"doc_count": 1, "category.keyword": {"buckets": [{"key": "sci-fi", "doc_count": 1}]}}
+			}]}
+		}
+	}`
+
+	aggregations := elastic.Aggregations{}
+
+	assert.Nil(t, json.Unmarshal([]byte(raw), &aggregations))
+
+	response, err := builder.processAggregations(aggregations)
+
+	assert.Nil(t, err)
+
+	bucket := response["cast.roles.name"].Buckets[0]
+
+	assert.Equal(t, "Sam Worthington", bucket.Items["cast.name"].Buckets[0].Key)
+	assert.Equal(t, "Fox", bucket.Items["studio.name"].Buckets[0].Key)
+	assert.Equal(t, "sci-fi", bucket.Items["category.keyword"].Buckets[0].Key)
+
+	invalid := []*Builder{new(Builder), new(Builder), new(Builder)}
+
+	invalid[0].GroupBy("category.keyword")
+	invalid[0].GroupByField("category.keyword").Nested("category.keyword")
+	invalid[1].StatsField("cast.age").Nested("cast")
+	invalid[2].Stats("views")
+	invalid[2].StatsField("cast.age").Nested("cast")
+
+	for _, builder := range invalid {
+		if _, got := builder.searchSource(); got == nil {
+			t.Error("Expected errors but got ", got)
+		}
+	}
+}
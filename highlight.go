@@ -0,0 +1,116 @@
+package golastic
+
+import (
+	"errors"
+
+	elastic "github.com/alejandro-carstens/elasticfork"
+)
+
+// Highlight represents the struct in charge of configuring
+// the highlighting of the fields of the returned hits
+type Highlight struct {
+	fields            []string
+	preTags           []string
+	postTags          []string
+	fragmentSize      *int
+	numberOfFragments *int
+	highlighterType   string
+	requireFieldMatch *bool
+}
+
+// PreTags sets the tags to be placed before each highlighted term
+func (h *Highlight) PreTags(tags ...string) *Highlight {
+	h.preTags = tags
+
+	return h
+}
+
+// PostTags sets the tags to be placed after each highlighted term
+func (h *Highlight) PostTags(tags ...string) *Highlight {
+	h.postTags = tags
+
+	return h
+}
+
+// FragmentSize sets the size in characters of the highlighted fragments
+func (h *Highlight) FragmentSize(size int) *Highlight {
+	h.fragmentSize = &size
+
+	return h
+}
+
+// NumberOfFragments sets the maximum number of fragments to return per field,
+// when set to 0 the whole content of the field gets highlighted
+func (h *Highlight) NumberOfFragments(number int) *Highlight {
+	h.numberOfFragments = &number
+
+	return h
+}
+
+// Type sets the highlighter to use (unified, plain or fvh)
+func (h *Highlight) Type(highlighterType string) *Highlight {
+	h.highlighterType = highlighterType
+
+	return h
+}
+
+// RequireFieldMatch sets whether only the fields that matched the query get highlighted
+func (h *Highlight) RequireFieldMatch(require bool) *Highlight {
+	h.requireFieldMatch = &require
+
+	return h
+}
+
+func (h *Highlight) validate() error {
+	if len(h.fields) == 0 {
+		return errors.New("Please specify at least a field to highlight")
+	}
+
+	if len(h.preTags) != len(h.postTags) {
+		return errors.New("The number of pre tags needs to match the number of post tags.")
+	}
+
+	if h.fragmentSize != nil && *h.fragmentSize <= 0 {
+		return errors.New("The fragment size needs to be greater than 0.")
+	}
+
+	if h.numberOfFragments != nil && *h.numberOfFragments < 0 {
+		return errors.New("The number of fragments needs to be greater or equal to 0.")
+	}
+
+	if len(h.highlighterType) > 0 && !inSlice(h.highlighterType, "unified", "plain", "fvh") {
+		return errors.New("The highlighter type is invalid.")
+	}
+
+	return nil
+}
+
+func (h *Highlight) highlight() *elastic.Highlight {
+	highlight := elastic.NewHighlight()
+
+	for _, field := range h.fields {
+		highlight = highlight.Field(field)
+	}
+
+	if len(h.preTags) > 0 {
+		highlight = highlight.PreTags(h.preTags...).PostTags(h.postTags...)
+	}
+
+	if h.fragmentSize != nil {
+		highlight = highlight.FragmentSize(*h.fragmentSize)
+	}
+
+	if h.numberOfFragments != nil {
+		highlight = highlight.NumOfFragments(*h.numberOfFragments)
+	}
+
+	if len(h.highlighterType) > 0 {
+		highlight = highlight.HighlighterType(h.highlighterType)
+	}
+
+	if h.requireFieldMatch != nil {
+		highlight = highlight.RequireFieldMatch(*h.requireFieldMatch)
+	}
+
+	return highlight
+}
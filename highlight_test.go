@@ -0,0 +1,64 @@
+package golastic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHighlightValidation(t *testing.T) {
+	builder := new(Builder)
+	builder.Highlight("title", "description").
+		PreTags("<em>").
+		PostTags("</em>").
+		FragmentSize(150).
+		NumberOfFragments(3).
+		Type("unified").
+		RequireFieldMatch(false)
+
+	if got := builder.highlight.validate(); got != nil {
+		t.Error("Expected no errors but got ", got)
+	}
+
+	builder = new(Builder)
+	builder.Highlight()
+
+	if got := builder.highlight.validate(); got == nil {
+		t.Error("Expected errors but got ", got)
+	}
+
+	builder = new(Builder)
+	builder.Highlight("title").PreTags("<em>", "<b>").PostTags("</em>")
+
+	if got := builder.highlight.validate(); got == nil {
+		t.Error("Expected errors but got ", got)
+	}
+
+	builder = new(Builder)
+	builder.Highlight("title").Type("fancy")
+
+	if got := builder.highlight.validate(); got == nil {
+		t.Error("Expected errors but got ", got)
+	}
+}
+
+func TestHighlightSource(t *testing.T) {
+	builder := new(Builder)
+	builder.Highlight("title").PreTags("<em>").PostTags("</em>").NumberOfFragments(0)
+
+	source, err := builder.highlight.highlight().Source()
+
+	if err != nil {
+		t.Error("Expected no errors but got ", err)
+	}
+
+	container, err := toGabsContainer(source)
+
+	if err != nil {
+		t.Error("Expected no errors but got ", err)
+	}
+
+	assert.Equal(t, "<em>", container.Path("pre_tags").Index(0).Data().(string))
+	assert.Equal(t, float64(0), container.Path("number_of_fragments").Data().(float64))
+	assert.NotNil(t, container.Path("fields.title").Data())
+}
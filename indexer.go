@@ -62,6 +62,34 @@ func (i *Indexer) CreateIndex(name string, schema string) error {
 	return nil
 }
 
+// CreatePercolatorIndex creates an index with a percolator field for storing queries, the properties
+// need to include the mappings of the document fields the registered queries refer to
+func (i *Indexer) CreatePercolatorIndex(name string, field string, properties map[string]interface{}) error {
+	if len(field) == 0 {
+		field = PERCOLATOR_FIELD
+	}
+
+	mappings := map[string]interface{}{}
+
+	for property, mapping := range properties {
+		mappings[property] = mapping
+	}
+
+	mappings[field] = map[string]interface{}{"type": "percolator"}
+
+	schema, err := toJson(map[string]interface{}{
+		"mappings": map[string]interface{}{
+			"properties": mappings,
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return i.CreateIndex(name, schema)
+}
+
 // DeleteIndex deletes an ElasticSearch Index
 func (i *Indexer) DeleteIndex(name string) error {
 	service := i.client.DeleteIndex(name)
@@ -0,0 +1,84 @@
+package golastic
+
+import (
+	"errors"
+
+	elastic "github.com/alejandro-carstens/elasticfork"
+)
+
+// InnerHits represents the struct in charge of configuring the inner hits
+// returned for collapsed fields and nested queries
+type InnerHits struct {
+	name  string
+	size  *int
+	from  *int
+	sorts []*sort
+}
+
+// Name sets the name under which the inner hits are returned
+func (ih *InnerHits) Name(name string) *InnerHits {
+	ih.name = name
+
+	return ih
+}
+
+// Size sets the maximum number of inner hits to be returned
+func (ih *InnerHits) Size(size int) *InnerHits {
+	ih.size = &size
+
+	return ih
+}
+
+// From sets the offset from which the inner hits will be returned
+func (ih *InnerHits) From(from int) *InnerHits {
+	ih.from = &from
+
+	return ih
+}
+
+// OrderBy sets the sorting order of the inner hits. Use true for ascending and false for descending
+func (ih *InnerHits) OrderBy(field string, asc bool) *InnerHits {
+	ih.sorts = append(ih.sorts, &sort{Field: field, Order: asc})
+
+	return ih
+}
+
+func (ih *InnerHits) validate() error {
+	if ih.size != nil && *ih.size < 0 {
+		return errors.New("The inner hits size needs to be greater or equal to 0.")
+	}
+
+	if ih.from != nil && *ih.from < 0 {
+		return errors.New("The inner hits from needs to be greater or equal to 0.")
+	}
+
+	for _, sort := range ih.sorts {
+		if len(sort.Field) == 0 {
+			return errors.New("field cannot be empty")
+		}
+	}
+
+	return nil
+}
+
+func (ih *InnerHits) innerHit() *elastic.InnerHit {
+	innerHit := elastic.NewInnerHit()
+
+	if len(ih.name) > 0 {
+		innerHit = innerHit.Name(ih.name)
+	}
+
+	if ih.size != nil {
+		innerHit = innerHit.Size(*ih.size)
+	}
+
+	if ih.from != nil {
+		innerHit = innerHit.From(*ih.from)
+	}
+
+	for _, sort := range ih.sorts {
+		innerHit = innerHit.Sort(sort.Field, sort.Order)
+	}
+
+	return innerHit
+}
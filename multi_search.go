@@ -0,0 +1,177 @@
+package golastic
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Jeffail/gabs"
+	elastic "github.com/alejandro-carstens/elasticfork"
+)
+
+// MultiSearch represents the struct in charge of executing the
+// search queries of several builders in a single _msearch request
+type MultiSearch struct {
+	builders              []*Builder
+	maxConcurrentSearches *int
+	client                *elastic.Client
+	context               context.Context
+}
+
+// MaxConcurrentSearches sets the maximum number of searches elasticsearch executes concurrently
+func (ms *MultiSearch) MaxConcurrentSearches(max int) *MultiSearch {
+	ms.maxConcurrentSearches = &max
+
+	return ms
+}
+
+// Do executes the searches and returns a result per builder in the order the builders were
+// given. A builder whose query is invalid or whose search failed reports the error on its result
+func (ms *MultiSearch) Do() ([]*MultiSearchResult, error) {
+	if len(ms.builders) == 0 {
+		return nil, errors.New("Please specify at least a builder")
+	}
+
+	if ms.maxConcurrentSearches != nil && *ms.maxConcurrentSearches <= 0 {
+		return nil, errors.New("The max concurrent searches needs to be greater than 0.")
+	}
+
+	service := ms.client.MultiSearch()
+
+	if ms.maxConcurrentSearches != nil {
+		service = service.MaxConcurrentSearches(*ms.maxConcurrentSearches)
+	}
+
+	results := []*MultiSearchResult{}
+	positions := []int{}
+
+	for i, builder := range ms.builders {
+		result := &MultiSearchResult{builder: builder}
+
+		results = append(results, result)
+
+		source, err := builder.searchSource()
+
+		if err != nil {
+			result.err = err
+
+			continue
+		}
+
+		request := elastic.NewSearchRequest().Index(builder.searchIndices()...).SearchSource(source.TrackTotalHits(true))
+
+		if builder.indicesOpts != nil {
+			request = request.
+				IgnoreUnavailable(builder.indicesOpts.IgnoreUnavailable).
+				AllowNoIndices(builder.indicesOpts.AllowNoIndices)
+		}
+
+		if len(builder.routing) > 0 {
+			request = request.Routing(builder.routing)
+		}
+
+		if len(builder.preference) > 0 {
+			request = request.Preference(builder.preference)
+		}
+
+		service = service.Add(request)
+
+		positions = append(positions, i)
+	}
+
+	if len(positions) == 0 {
+		return results, nil
+	}
+
+	response, err := service.Do(ms.context)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(response.Responses) != len(positions) {
+		return nil, errors.New("The number of responses does not match the number of searches.")
+	}
+
+	for i, position := range positions {
+		if response.Responses[i].Error != nil {
+			results[position].err = &elastic.Error{Details: response.Responses[i].Error}
+
+			continue
+		}
+
+		results[position].response = response.Responses[i]
+	}
+
+	return results, nil
+}
+
+// MultiSearchResult represents the result of a builder's search within a
+// multi search, it is decoded the same way the builder decodes its results
+type MultiSearchResult struct {
+	builder  *Builder
+	response *elastic.SearchResult
+	err      error
+}
+
+// Err returns the error of the builder's search, if any
+func (msr *MultiSearchResult) Err() error {
+	return msr.err
+}
+
+// Get decodes the hits into items the same way Builder.Get does
+func (msr *MultiSearchResult) Get(items interface{}) error {
+	if msr.err != nil {
+		return msr.err
+	}
+
+	return msr.builder.decodeHits(msr.response, items)
+}
+
+// Search decodes the hits into items and returns their metadata the same way Builder.Search does
+func (msr *MultiSearchResult) Search(items interface{}) (*SearchResponse, error) {
+	if msr.err != nil {
+		return nil, msr.err
+	}
+
+	return msr.builder.decodeSearchResponse(msr.response, items)
+}
+
+// Count returns the number of documents matching the builder's query
+func (msr *MultiSearchResult) Count() (int64, error) {
+	if msr.err != nil {
+		return 0, msr.err
+	}
+
+	return msr.response.TotalHits(), nil
+}
+
+// Aggregate returns the builder's aggregations the same way Builder.Aggregate does
+func (msr *MultiSearchResult) Aggregate() (map[string]*AggregationResponse, error) {
+	if msr.err != nil {
+		return nil, msr.err
+	}
+
+	return msr.builder.decodeAggregations(msr.response)
+}
+
+// AggregateRaw returns the raw aggregation results
+func (msr *MultiSearchResult) AggregateRaw() (*gabs.Container, error) {
+	if msr.err != nil {
+		return nil, msr.err
+	}
+
+	if msr.response.Aggregations == nil {
+		return nil, errors.New("No aggregations returned")
+	}
+
+	return toGabsContainer(msr.response.Aggregations)
+}
+
+// Suggest returns the results of the builder's suggesters
+func (msr *MultiSearchResult) Suggest() (SuggestResponses, error) {
+	if msr.err != nil {
+		return nil, msr.err
+	}
+
+	return processSuggestions(msr.response.Suggest), nil
+}
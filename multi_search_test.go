@@ -0,0 +1,89 @@
+package golastic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiSearchValidation(t *testing.T) {
+	connection := NewConnection(&ConnectionContext{})
+
+	if _, err := connection.MultiSearch().Do(); err == nil {
+		t.Error("Expected errors but got ", err)
+	}
+
+	if _, err := connection.MultiSearch(connection.Builder("example")).MaxConcurrentSearches(0).Do(); err == nil {
+		t.Error("Expected errors but got ", err)
+	}
+
+	builder := connection.Builder("example")
+	builder.Where("subject_id", ">", "many")
+
+	results, err := connection.MultiSearch(builder).Do()
+
+	if err != nil {
+		t.Error("Expected no errors but got ", err)
+	}
+
+	assert.Equal(t, 1, len(results))
+
+	if _, err := results[0].Count(); err == nil {
+		t.Error("Expected errors but got ", err)
+	}
+}
+
+func TestMultiSearch(t *testing.T) {
+	connection, err := initConnection()
+
+	if err != nil {
+		t.Error("Expected no error got:", err)
+	}
+
+	if _, err = connection.Builder("example").Insert(seedModels(10)...); err != nil {
+		t.Error("Expected no error on insert:", err)
+	}
+
+	time.Sleep(1 * time.Second)
+
+	count := connection.Builder("example")
+	count.Where("subject_id", "=", 1)
+
+	get := connection.Builder("example")
+	get.Where("description", "=", "Description 2")
+
+	aggregate := connection.Builder("example")
+	aggregate.GroupBy("subject_id")
+
+	invalid := connection.Builder("example")
+	invalid.Where("subject_id", ">", "many")
+
+	results, err := connection.MultiSearch(count, get, invalid, aggregate).MaxConcurrentSearches(2).Do()
+
+	if err != nil {
+		t.Error("Expected no error got:", err)
+	}
+
+	total, err := results[0].Count()
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), total)
+
+	examples := []*Example{}
+
+	assert.Nil(t, results[1].Get(&examples))
+	assert.Equal(t, 1, len(examples))
+	assert.Equal(t, "2", examples[0].Id)
+
+	assert.NotNil(t, results[2].Err())
+
+	aggregations, err := results[3].Aggregate()
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(aggregations["subject_id"].Buckets))
+
+	if err := tearDownBuilder(connection); err != nil {
+		t.Error("Expected no error got:", err)
+	}
+}
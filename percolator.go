@@ -0,0 +1,95 @@
+package golastic
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Jeffail/gabs"
+	elastic "github.com/alejandro-carstens/elasticfork"
+)
+
+// Percolator represents the struct in charge of registering queries into
+// a percolator index and finding which of them match a given document
+type Percolator struct {
+	index   string
+	field   string
+	client  *elastic.Client
+	context context.Context
+}
+
+// Field sets the percolator field the registered queries are stored in
+func (p *Percolator) Field(field string) *Percolator {
+	p.field = field
+
+	return p
+}
+
+// Register stores the query of the given builder under the specified id
+func (p *Percolator) Register(id string, builder *Builder) (*gabs.Container, error) {
+	if len(id) == 0 {
+		return nil, errors.New("id cannot be empty")
+	}
+
+	source, err := p.querySource(builder)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return parse(p.client.Index().Index(p.index).Id(id).BodyJson(map[string]interface{}{
+		p.field: source,
+	}).Do(p.context))
+}
+
+// Unregister removes the queries registered under the given ids
+func (p *Percolator) Unregister(ids ...string) (*gabs.Container, error) {
+	return p.builder().Delete(ids...)
+}
+
+// Percolate returns the ids of every registered query that matches any of the given documents
+func (p *Percolator) Percolate(docs ...interface{}) ([]string, error) {
+	if len(docs) == 0 {
+		return nil, errors.New("Please specify at least a document to percolate.")
+	}
+
+	scroll := p.client.Scroll(p.index).
+		Query(elastic.NewPercolatorQuery().Field(p.field).Document(docs...)).
+		FetchSource(false).
+		Size(LIMIT)
+
+	ids := []string{}
+
+	err := scrollHits(p.context, scroll, func(hits []*elastic.SearchHit) error {
+		for _, hit := range hits {
+			ids = append(ids, hit.Id)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func (p *Percolator) querySource(builder *Builder) (interface{}, error) {
+	if builder == nil {
+		return nil, errors.New("Please specify the builder holding the query to register.")
+	}
+
+	if err := builder.validateMustClauses(); err != nil {
+		return nil, err
+	}
+
+	return builder.query().Source()
+}
+
+func (p *Percolator) builder() *Builder {
+	return &Builder{
+		client:  p.client,
+		index:   p.index,
+		context: p.context,
+	}
+}
@@ -0,0 +1,86 @@
+package golastic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPercolatorQuerySource(t *testing.T) {
+	percolator := &Percolator{index: "alerts", field: PERCOLATOR_FIELD}
+
+	builder := new(Builder)
+	builder.Where("subject_id", "=", 1).Match("description", "=", "golang")
+
+	source, err := percolator.querySource(builder)
+
+	assert.Nil(t, err)
+
+	container, err := toGabsContainer(source)
+
+	assert.Nil(t, err)
+	assert.True(t, container.Exists("bool", "must"))
+
+	builder = new(Builder)
+	builder.Where("subject_id", ">", "many")
+
+	if _, err := percolator.querySource(builder); err == nil {
+		t.Error("Expected errors but got ", err)
+	}
+
+	if _, err := percolator.querySource(nil); err == nil {
+		t.Error("Expected errors but got ", err)
+	}
+}
+
+func TestPercolate(t *testing.T) {
+	connection, err := bootConnection()
+
+	if err != nil {
+		t.Error("Expected no error got:", err)
+	}
+
+	err = connection.Indexer(nil).CreatePercolatorIndex("alerts", "", map[string]interface{}{
+		"description": map[string]interface{}{"type": "keyword"},
+		"subject_id":  map[string]interface{}{"type": "integer"},
+	})
+
+	if err != nil {
+		t.Error("Expected no error got:", err)
+	}
+
+	percolator := connection.Percolator("alerts")
+
+	first := connection.Builder("alerts")
+	first.Where("subject_id", "=", 1)
+
+	second := connection.Builder("alerts")
+	second.Where("description", "=", "Description 2")
+
+	if _, err := percolator.Register("first", first); err != nil {
+		t.Error("Expected no error got:", err)
+	}
+
+	if _, err := percolator.Register("second", second); err != nil {
+		t.Error("Expected no error got:", err)
+	}
+
+	time.Sleep(1 * time.Second)
+
+	ids, err := percolator.Percolate(seedModels(1)...)
+
+	if err != nil {
+		t.Error("Expected no error got:", err)
+	}
+
+	assert.Equal(t, []string{"first"}, ids)
+
+	if _, err := percolator.Unregister("first"); err != nil {
+		t.Error("Expected no error got:", err)
+	}
+
+	if err := connection.Indexer(nil).DeleteIndex("alerts"); err != nil {
+		t.Error("Expected no error got:", err)
+	}
+}
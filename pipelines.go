@@ -0,0 +1,328 @@
+package golastic
+
+import (
+	"errors"
+	"strings"
+
+	elastic "github.com/alejandro-carstens/elasticfork"
+)
+
+// BucketSortAgg creates a bucket_sort pipeline aggregation which sorts and truncates the buckets
+// of its parent aggregation, use OrderBy for sorting by a buckets path and Size and From for truncating
+func BucketSortAgg() *Aggregation {
+	return newAggregation("bucket_sort", "")
+}
+
+// BucketSelectorAgg creates a bucket_selector pipeline aggregation which keeps the buckets of its parent
+// aggregation for which the script returns true, the paths map the script params to buckets paths
+func BucketSelectorAgg(script string, paths map[string]string) *Aggregation {
+	aggregation := newAggregation("bucket_selector", "")
+	aggregation.script = script
+	aggregation.bucketsPaths = paths
+
+	return aggregation
+}
+
+// BucketScriptAgg creates a bucket_script pipeline aggregation which computes a value for each bucket of
+// its parent aggregation through the given script, the paths map the script params to buckets paths
+func BucketScriptAgg(script string, paths map[string]string) *Aggregation {
+	aggregation := newAggregation("bucket_script", "")
+	aggregation.script = script
+	aggregation.bucketsPaths = paths
+
+	return aggregation
+}
+
+// DerivativeAgg creates a derivative pipeline aggregation of the given buckets path within its parent histogram
+func DerivativeAgg(path string) *Aggregation {
+	return newPipelineAggregation("derivative", path)
+}
+
+// CumulativeSumAgg creates a cumulative_sum pipeline aggregation of the given buckets path within its parent histogram
+func CumulativeSumAgg(path string) *Aggregation {
+	return newPipelineAggregation("cumulative_sum", path)
+}
+
+// MovingFnAgg creates a moving_fn pipeline aggregation which runs the given script over a sliding
+// window of the given buckets path within its parent histogram, i.e. "MovingFunctions.unweightedAvg(values)"
+func MovingFnAgg(path string, script string, window int) *Aggregation {
+	aggregation := newPipelineAggregation("moving_fn", path)
+	aggregation.script = script
+	aggregation.window = window
+
+	return aggregation
+}
+
+// AvgBucketAgg creates an avg_bucket sibling pipeline aggregation which averages
+// the given buckets path across the buckets of a sibling aggregation, i.e. "sales_per_month>sales"
+func AvgBucketAgg(path string) *Aggregation {
+	return newPipelineAggregation("avg_bucket", path)
+}
+
+// MaxBucketAgg creates a max_bucket sibling pipeline aggregation which returns the maximum
+// value and keys of the given buckets path across the buckets of a sibling aggregation
+func MaxBucketAgg(path string) *Aggregation {
+	return newPipelineAggregation("max_bucket", path)
+}
+
+func newPipelineAggregation(aggType string, path string) *Aggregation {
+	aggregation := newAggregation(aggType, "")
+	aggregation.bucketsPaths = map[string]string{"": path}
+
+	return aggregation
+}
+
+// From sets the number of buckets to be skipped by a bucket_sort aggregation
+func (a *Aggregation) From(from int) *Aggregation {
+	a.from = &from
+
+	return a.option("from")
+}
+
+// GapPolicy sets how a pipeline aggregation handles the gaps in the data, either "skip" or "insert_zeros"
+func (a *Aggregation) GapPolicy(policy string) *Aggregation {
+	a.gapPolicy = policy
+
+	return a.option("gap_policy")
+}
+
+func (a *Aggregation) isPipelineAggregation() bool {
+	return a.isParentPipelineAggregation() || inSlice(a.aggType, "avg_bucket", "max_bucket")
+}
+
+func (a *Aggregation) isParentPipelineAggregation() bool {
+	return inSlice(
+		a.aggType,
+		"bucket_sort",
+		"bucket_selector",
+		"bucket_script",
+		"derivative",
+		"cumulative_sum",
+		"moving_fn",
+	)
+}
+
+func (a *Aggregation) validatePipelineSettings() error {
+	if a.aggType == "bucket_sort" {
+		if len(a.sorts) == 0 && a.size == nil && a.from == nil {
+			return errors.New("Please specify a sort, a size or a from for the bucket sort.")
+		}
+
+		if a.from != nil && *a.from < 0 {
+			return errors.New("The from cannot be negative.")
+		}
+
+		return nil
+	}
+
+	if len(a.bucketsPaths) == 0 {
+		return errors.New("Please specify at least a buckets path.")
+	}
+
+	for _, path := range a.bucketsPaths {
+		if len(path) == 0 {
+			return errors.New("path cannot be empty")
+		}
+	}
+
+	if inSlice(a.aggType, "bucket_selector", "bucket_script", "moving_fn") && len(a.script) == 0 {
+		return errors.New("script cannot be empty")
+	}
+
+	if a.aggType == "moving_fn" && a.window <= 0 {
+		return errors.New("The window needs to be greater than 0.")
+	}
+
+	if len(a.gapPolicy) > 0 && !inSlice(a.gapPolicy, "skip", "insert_zeros") {
+		return errors.New("The gap policy needs to be either skip or insert_zeros.")
+	}
+
+	return nil
+}
+
+func (a *Aggregation) pathsToValidate() []string {
+	paths := []string{}
+
+	for _, sort := range a.sorts {
+		paths = append(paths, sort.Field)
+	}
+
+	for _, path := range a.bucketsPaths {
+		paths = append(paths, path)
+	}
+
+	return paths
+}
+
+// validatePipelines checks the buckets paths of the pipeline aggregations found among the given
+// sibling aggregations, parent is the aggregation the siblings are nested under if any
+func validatePipelines(aggregations []*Aggregation, parent *Aggregation) error {
+	for _, aggregation := range aggregations {
+		if !aggregation.isPipelineAggregation() {
+			continue
+		}
+
+		if aggregation.isParentPipelineAggregation() {
+			if parent == nil || !parent.isBucketAggregation() || parent.isSingleBucketAggregation() {
+				return errors.New("The " + aggregation.aggType + " aggregation needs to be nested under a multi-bucket aggregation.")
+			}
+
+			if inSlice(aggregation.aggType, "derivative", "cumulative_sum", "moving_fn") &&
+				!inSlice(parent.aggType, "histogram", "date_histogram") {
+				return errors.New("The " + aggregation.aggType + " aggregation needs to be nested under a histogram or date_histogram aggregation.")
+			}
+		}
+
+		siblings := []*Aggregation{}
+
+		for _, sibling := range aggregations {
+			if sibling != aggregation {
+				siblings = append(siblings, sibling)
+			}
+		}
+
+		for _, path := range aggregation.pathsToValidate() {
+			if err := validateBucketsPath(path, siblings, aggregation.isParentPipelineAggregation()); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateBucketsPath(path string, siblings []*Aggregation, isParentPipeline bool) error {
+	segments := strings.Split(path, ">")
+
+	if !isParentPipeline && len(segments) < 2 {
+		return errors.New("The buckets path " + path + " needs to reference a metric within a multi-bucket aggregation.")
+	}
+
+	for i, segment := range segments {
+		isLast := i == len(segments)-1
+		name := segment
+
+		if isLast {
+			name = strings.SplitN(strings.SplitN(segment, ".", 2)[0], "[", 2)[0]
+
+			if inSlice(name, "_count", "_key") || (name == "_bucket_count" && i > 0) {
+				return nil
+			}
+		}
+
+		var aggregation *Aggregation
+
+		for _, sibling := range siblings {
+			if sibling.name == name {
+				aggregation = sibling
+			}
+		}
+
+		if aggregation == nil {
+			return errors.New("The buckets path " + path + " does not match any aggregation.")
+		}
+
+		if isLast {
+			if aggregation.isBucketAggregation() && !aggregation.isSingleBucketAggregation() {
+				return errors.New("The buckets path " + path + " needs to end on a metric or a single-bucket aggregation.")
+			}
+
+			return nil
+		}
+
+		if !aggregation.isBucketAggregation() {
+			return errors.New("The buckets path " + path + " can only go through bucket aggregations.")
+		}
+
+		if isParentPipeline && !aggregation.isSingleBucketAggregation() {
+			return errors.New("The buckets path " + path + " can only go through single-bucket aggregations.")
+		}
+
+		if !isParentPipeline && i == 0 && aggregation.isSingleBucketAggregation() {
+			return errors.New("The buckets path " + path + " needs to start on a multi-bucket aggregation.")
+		}
+
+		siblings = aggregation.subAggregations
+	}
+
+	return nil
+}
+
+func (a *Aggregation) pipelineAggregation() elastic.Aggregation {
+	switch a.aggType {
+	case "bucket_sort":
+		aggregation := elastic.NewBucketSortAggregation()
+
+		for _, sort := range a.sorts {
+			aggregation = aggregation.Sort(sort.Field, sort.Order)
+		}
+
+		if a.size != nil {
+			aggregation = aggregation.Size(*a.size)
+		}
+
+		if a.from != nil {
+			aggregation = aggregation.From(*a.from)
+		}
+
+		if len(a.gapPolicy) > 0 {
+			aggregation = aggregation.GapPolicy(a.gapPolicy)
+		}
+
+		return aggregation
+	case "bucket_selector":
+		aggregation := elastic.NewBucketSelectorAggregation().
+			Script(elastic.NewScript(a.script)).
+			BucketsPathsMap(a.bucketsPaths)
+
+		if len(a.gapPolicy) > 0 {
+			aggregation = aggregation.GapPolicy(a.gapPolicy)
+		}
+
+		return aggregation
+	case "bucket_script":
+		aggregation := elastic.NewBucketScriptAggregation().
+			Script(elastic.NewScript(a.script)).
+			BucketsPathsMap(a.bucketsPaths)
+
+		if len(a.gapPolicy) > 0 {
+			aggregation = aggregation.GapPolicy(a.gapPolicy)
+		}
+
+		return aggregation
+	case "derivative":
+		aggregation := elastic.NewDerivativeAggregation().BucketsPath(a.bucketsPaths[""])
+
+		if len(a.gapPolicy) > 0 {
+			aggregation = aggregation.GapPolicy(a.gapPolicy)
+		}
+
+		return aggregation
+	case "cumulative_sum":
+		return elastic.NewCumulativeSumAggregation().BucketsPath(a.bucketsPaths[""])
+	case "moving_fn":
+		aggregation := elastic.NewMovFnAggregation(a.bucketsPaths[""], elastic.NewScript(a.script), a.window)
+
+		if len(a.gapPolicy) > 0 {
+			aggregation = aggregation.GapPolicy(a.gapPolicy)
+		}
+
+		return aggregation
+	case "avg_bucket":
+		aggregation := elastic.NewAvgBucketAggregation().BucketsPath(a.bucketsPaths[""])
+
+		if len(a.gapPolicy) > 0 {
+			aggregation = aggregation.GapPolicy(a.gapPolicy)
+		}
+
+		return aggregation
+	}
+
+	aggregation := elastic.NewMaxBucketAggregation().BucketsPath(a.bucketsPaths[""])
+
+	if len(a.gapPolicy) > 0 {
+		aggregation = aggregation.GapPolicy(a.gapPolicy)
+	}
+
+	return aggregation
+}
@@ -0,0 +1,145 @@
+package golastic
+
+import (
+	"encoding/json"
+	"testing"
+
+	elastic "github.com/alejandro-carstens/elasticfork"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipelineValidation(t *testing.T) {
+	valid := []*Builder{
+		new(Builder).Aggregation(
+			"per_month",
+			DateHistogramAgg("created_at", "month").
+				SubAggregation("sales", SumAgg("amount")).
+				SubAggregation("growth", DerivativeAgg("sales")).
+				SubAggregation("total", CumulativeSumAgg("sales")).
+				SubAggregation("trend", MovingFnAgg("sales", "MovingFunctions.unweightedAvg(values)", 3)),
+		).Aggregation("avg_monthly_sales", AvgBucketAgg("per_month>sales")),
+		new(Builder).Aggregation(
+			"categories",
+			TermsAgg("category").
+				SubAggregation("revenue", StatsAgg("amount")).
+				SubAggregation("unknown", MissingAgg("brand").SubAggregation("revenue", SumAgg("amount"))).
+				SubAggregation("share", BucketScriptAgg("params.unknown / params.total", map[string]string{
+					"unknown": "unknown>revenue",
+					"total":   "revenue.sum",
+				})).
+				SubAggregation("popular", BucketSelectorAgg("params.count > 10", map[string]string{"count": "_count"})).
+				SubAggregation("top", BucketSortAgg().OrderBy("share", false).Size(5)),
+		).Aggregation("best_category", MaxBucketAgg("categories>revenue.max")),
+		new(Builder).Aggregation(
+			"categories",
+			TermsAgg("category").
+				SubAggregation("last_year", FilterAgg(func(builder *Builder) {
+					builder.Where("created_at", "<", "2019-01-01")
+				}).SubAggregation("revenue", SumAgg("amount"))).
+				SubAggregation("revenue", SumAgg("amount")).
+				SubAggregation("growth", BucketScriptAgg("params.current / params.previous", map[string]string{
+					"current":  "revenue",
+					"previous": "last_year>revenue",
+				})).
+				SubAggregation("top", BucketSortAgg().OrderBy("growth", false).Size(5)),
+		),
+	}
+
+	for _, builder := range valid {
+		if _, got := builder.searchSource(); got != nil {
+			t.Error("Expected no errors but got ", got)
+		}
+	}
+
+	invalid := []*Builder{
+		new(Builder).Aggregation("growth", DerivativeAgg("sales")),
+		new(Builder).Aggregation("recent", FilterAgg(func(builder *Builder) {
+			builder.Where("created_at", ">=", "2019-01-01")
+		}).SubAggregation("sales", SumAgg("amount")).SubAggregation("top", BucketSortAgg().Size(5))),
+		new(Builder).Aggregation("categories", TermsAgg("category").SubAggregation("sales", SumAgg("amount")).SubAggregation(
+			"growth", DerivativeAgg("sales"),
+		)),
+		new(Builder).Aggregation("per_month", DateHistogramAgg("created_at", "month").SubAggregation(
+			"growth", DerivativeAgg("sales"),
+		)),
+		new(Builder).Aggregation("per_month", DateHistogramAgg("created_at", "month").SubAggregation(
+			"growth", DerivativeAgg(""),
+		)),
+		new(Builder).Aggregation("per_month", DateHistogramAgg("created_at", "month").SubAggregation(
+			"sales", SumAgg("amount"),
+		).SubAggregation("growth", DerivativeAgg("sales").GapPolicy("ignore"))),
+		new(Builder).Aggregation("per_month", DateHistogramAgg("created_at", "month").SubAggregation(
+			"sales", SumAgg("amount"),
+		).SubAggregation("trend", MovingFnAgg("sales", "", 3))),
+		new(Builder).Aggregation("per_month", DateHistogramAgg("created_at", "month").SubAggregation(
+			"sales", SumAgg("amount"),
+		).SubAggregation("trend", MovingFnAgg("sales", "MovingFunctions.max(values)", 0))),
+		new(Builder).Aggregation("categories", TermsAgg("category").SubAggregation("top", BucketSortAgg())),
+		new(Builder).Aggregation("categories", TermsAgg("category").SubAggregation(
+			"top", BucketSortAgg().OrderBy("revenue", false),
+		)),
+		new(Builder).Aggregation("categories", TermsAgg("category").SubAggregation(
+			"brands", TermsAgg("brand").SubAggregation("revenue", SumAgg("amount")),
+		).SubAggregation("top", BucketSortAgg().OrderBy("brands>revenue", false))),
+		new(Builder).Aggregation("categories", TermsAgg("category").SubAggregation(
+			"brands", TermsAgg("brand"),
+		).SubAggregation("top", BucketSortAgg().OrderBy("brands", false))),
+		new(Builder).Aggregation("categories", TermsAgg("category")).Aggregation("best", MaxBucketAgg("categories")),
+		new(Builder).Aggregation("revenue", SumAgg("amount")).Aggregation("best", MaxBucketAgg("revenue>value")),
+		new(Builder).Aggregation("categories", TermsAgg("category")).Aggregation("best", MaxBucketAgg("categories>revenue")),
+	}
+
+	for _, builder := range invalid {
+		if _, got := builder.searchSource(); got == nil {
+			t.Error("Expected errors but got ", got)
+		}
+	}
+}
+
+func TestPipelineSource(t *testing.T) {
+	builder := new(Builder)
+	builder.Aggregation(
+		"categories",
+		TermsAgg("category").
+			SubAggregation("revenue", SumAgg("amount")).
+			SubAggregation("top", BucketSortAgg().OrderBy("revenue", false).Size(5).From(1)),
+	).Aggregation("best_category", MaxBucketAgg("categories>revenue")).Aggregation(
+		"recent",
+		FilterAgg(func(builder *Builder) { builder.Where("created_at", ">=", "2019-01-01") }).SubAggregation("revenue", SumAgg("amount")),
+	)
+
+	source, err := builder.searchSource()
+
+	assert.Nil(t, err)
+
+	data, err := source.Source()
+
+	assert.Nil(t, err)
+
+	container, err := toGabsContainer(data)
+
+	assert.Nil(t, err)
+	assert.Equal(t, float64(5), container.Path("aggregations.categories.aggregations.top.bucket_sort.size").Data())
+	assert.Equal(t, float64(1), container.Path("aggregations.categories.aggregations.top.bucket_sort.from").Data())
+	assert.Equal(t, "categories>revenue", container.Path("aggregations.best_category.max_bucket.buckets_path").Data())
+	assert.True(t, container.Exists("aggregations", "recent", "filter", "bool"))
+
+	raw := `{
+		"categories": {"buckets": [{"key": "shoes", "doc_count": 3, "revenue": {"value": 120}}]},
+		"best_category": {"value": 120, "keys": ["shoes"]},
+		"recent": {"doc_count": 2, "revenue": {"value": 80}}
+	}`
+
+	aggregations := elastic.Aggregations{}
+
+	assert.Nil(t, json.Unmarshal([]byte(raw), &aggregations))
+
+	response, err := builder.processAggregations(aggregations)
+
+	assert.Nil(t, err)
+	assert.Equal(t, float64(120), *response["best_category"].Value)
+	assert.Equal(t, []interface{}{"shoes"}, response["best_category"].Keys)
+	assert.Equal(t, float64(120), *response["categories"].Buckets[0].Items["revenue"].Value)
+	assert.Equal(t, 2, *response["recent"].DocCount)
+	assert.Equal(t, float64(80), *response["recent"].Items["revenue"].Value)
+}
@@ -0,0 +1,44 @@
+package golastic
+
+// geoShapeQuery implements elastic.Query for geo_shape queries which
+// are not provided by the elastic client
+type geoShapeQuery struct {
+	field       string
+	shapeType   string
+	coordinates interface{}
+	relation    string
+}
+
+func newGeoShapeQuery(field string, shapeType string, coordinates interface{}) *geoShapeQuery {
+	return &geoShapeQuery{
+		field:       field,
+		shapeType:   shapeType,
+		coordinates: coordinates,
+	}
+}
+
+func (q *geoShapeQuery) Relation(relation string) *geoShapeQuery {
+	q.relation = relation
+
+	return q
+}
+
+// Source returns the JSON-serializable query
+func (q *geoShapeQuery) Source() (interface{}, error) {
+	shape := map[string]interface{}{
+		"shape": map[string]interface{}{
+			"type":        q.shapeType,
+			"coordinates": q.coordinates,
+		},
+	}
+
+	if len(q.relation) > 0 {
+		shape["relation"] = q.relation
+	}
+
+	return map[string]interface{}{
+		"geo_shape": map[string]interface{}{
+			q.field: shape,
+		},
+	}, nil
+}
@@ -13,6 +13,26 @@ type nested struct {
 	filterIns    []*filterIn
 	matches      []*match
 	matchPhrases []*matchPhrase
+	innerHits    *InnerHits
+	geoClauses
+}
+
+func (n *nested) isEmpty() bool {
+	return len(n.wheres) == 0 &&
+		len(n.whereIns) == 0 &&
+		len(n.whereNotIns) == 0 &&
+		len(n.filters) == 0 &&
+		len(n.filterIns) == 0 &&
+		len(n.matches) == 0 &&
+		len(n.matchPhrases) == 0 &&
+		len(n.geoClauses.fields()) == 0
+}
+
+type geoClauses struct {
+	geoDistances     []*geoDistance
+	geoBoundingBoxes []*geoBoundingBox
+	geoPolygons      []*geoPolygon
+	geoShapes        []*geoShape
 }
 
 type queryBuilder struct {
@@ -34,70 +54,129 @@ type queryBuilder struct {
 	nested            map[string]*nested
 	nestedSort        *nestedSort
 	stats             *stats
+	geoDistanceSort   *geoDistanceSort
+	sourceFilter      *sourceFilter
+	docValueFields    []string
+	storedFields      []string
+	hasChilds         []*hasChild
+	hasParents        []*hasParent
+	parentIds         []*parentId
+	whereIds          []*whereIds
+	moreLikeThis      []*moreLikeThis
+	whereInLookups    []*whereInLookup
+	geoClauses
 }
 
-func (qb *queryBuilder) Where(field string, operand string, value interface{}) *queryBuilder {
-	qb.wheres = append(qb.wheres, &where{Field: field, Operand: operand, Value: value})
+func (qb *queryBuilder) Where(field string, operand string, value interface{}, options ...ClauseOption) *queryBuilder {
+	qb.wheres = append(qb.wheres, &where{
+		Field:   field,
+		Operand: operand,
+		Value:   value,
+		Options: newClauseOptions(options),
+	})
 
 	return qb
 }
 
-func (qb *queryBuilder) WhereIn(field string, values []interface{}) *queryBuilder {
-	qb.whereIns = append(qb.whereIns, &whereIn{Field: field, Values: values})
+func (qb *queryBuilder) WhereIn(field string, values []interface{}, options ...ClauseOption) *queryBuilder {
+	qb.whereIns = append(qb.whereIns, &whereIn{
+		Field:   field,
+		Values:  values,
+		Options: newClauseOptions(options),
+	})
 
 	return qb
 }
 
-func (qb *queryBuilder) WhereNotIn(field string, values []interface{}) *queryBuilder {
-	qb.whereNotIns = append(qb.whereNotIns, &whereNotIn{Field: field, Values: values})
+func (qb *queryBuilder) WhereNotIn(field string, values []interface{}, options ...ClauseOption) *queryBuilder {
+	qb.whereNotIns = append(qb.whereNotIns, &whereNotIn{
+		Field:   field,
+		Values:  values,
+		Options: newClauseOptions(options),
+	})
 
 	return qb
 }
 
-func (qb *queryBuilder) Filter(field string, operand string, value interface{}) *queryBuilder {
-	qb.filters = append(qb.filters, &filter{Field: field, Operand: operand, Value: value})
+func (qb *queryBuilder) Filter(field string, operand string, value interface{}, options ...ClauseOption) *queryBuilder {
+	qb.filters = append(qb.filters, &filter{
+		Field:   field,
+		Operand: operand,
+		Value:   value,
+		Options: newClauseOptions(options),
+	})
 
 	return qb
 }
 
-func (qb *queryBuilder) FilterIn(field string, values []interface{}) *queryBuilder {
-	qb.filterIns = append(qb.filterIns, &filterIn{Field: field, Values: values})
+func (qb *queryBuilder) FilterIn(field string, values []interface{}, options ...ClauseOption) *queryBuilder {
+	qb.filterIns = append(qb.filterIns, &filterIn{
+		Field:   field,
+		Values:  values,
+		Options: newClauseOptions(options),
+	})
 
 	return qb
 }
 
-func (qb *queryBuilder) Match(field string, operand string, value interface{}) *queryBuilder {
-	qb.matches = append(qb.matches, &match{Field: field, Operand: operand, Value: value})
+func (qb *queryBuilder) Match(field string, operand string, value interface{}, options ...ClauseOption) *queryBuilder {
+	qb.matches = append(qb.matches, &match{
+		Field:   field,
+		Operand: operand,
+		Value:   value,
+		Options: newClauseOptions(options),
+	})
 
 	return qb
 }
 
-func (qb *queryBuilder) MatchIn(field string, values []interface{}) *queryBuilder {
-	qb.matchIns = append(qb.matchIns, &matchIn{Field: field, Values: values})
+func (qb *queryBuilder) MatchIn(field string, values []interface{}, options ...ClauseOption) *queryBuilder {
+	qb.matchIns = append(qb.matchIns, &matchIn{
+		Field:   field,
+		Values:  values,
+		Options: newClauseOptions(options),
+	})
 
 	return qb
 }
 
-func (qb *queryBuilder) MatchNotIn(field string, values []interface{}) *queryBuilder {
-	qb.matchNotIns = append(qb.matchNotIns, &matchNotIn{Field: field, Values: values})
+func (qb *queryBuilder) MatchNotIn(field string, values []interface{}, options ...ClauseOption) *queryBuilder {
+	qb.matchNotIns = append(qb.matchNotIns, &matchNotIn{
+		Field:   field,
+		Values:  values,
+		Options: newClauseOptions(options),
+	})
 
 	return qb
 }
 
-func (qb *queryBuilder) MatchPhrase(field string, operand string, value interface{}) *queryBuilder {
-	qb.matchPhrases = append(qb.matchPhrases, &matchPhrase{Field: field, Operand: operand, Value: value})
+func (qb *queryBuilder) MatchPhrase(field string, operand string, value interface{}, options ...ClauseOption) *queryBuilder {
+	qb.matchPhrases = append(qb.matchPhrases, &matchPhrase{
+		Field:   field,
+		Operand: operand,
+		Value:   value,
+		Options: newClauseOptions(options),
+	})
 
 	return qb
 }
 
-func (qb *queryBuilder) MatchPhraseIn(field string, values []interface{}) *queryBuilder {
-	qb.matchPhraseIns = append(qb.matchPhraseIns, &matchPhraseIn{Field: field, Values: values})
+func (qb *queryBuilder) MatchPhraseIn(field string, values []interface{}, options ...ClauseOption) *queryBuilder {
+	qb.matchPhraseIns = append(qb.matchPhraseIns, &matchPhraseIn{
+		Field:   field,
+		Values:  values,
+		Options: newClauseOptions(options),
+	})
 
 	return qb
 }
 
-func (qb *queryBuilder) MatchPhraseNotIn(field string, values []interface{}) *queryBuilder {
-	qb.matchPhraseNotIns = append(qb.matchPhraseNotIns, &matchPhraseNotIn{Field: field, Values: values})
+func (qb *queryBuilder) MatchPhraseNotIn(field string, values []interface{}, options ...ClauseOption) *queryBuilder {
+	qb.matchPhraseNotIns = append(qb.matchPhraseNotIns, &matchPhraseNotIn{
+		Field:   field,
+		Values:  values,
+		Options: newClauseOptions(options),
+	})
 
 	return qb
 }
@@ -114,12 +193,16 @@ func (qb *queryBuilder) Limit(value int) *queryBuilder {
 	return qb
 }
 
+// GroupBy aggregates by the given fields, the fields using the object.property notation
+// are aggregated within a nested aggregation for their object path
 func (qb *queryBuilder) GroupBy(fields ...string) *queryBuilder {
 	qb.groupBy = &groupBy{Fields: fields}
 
 	return qb
 }
 
+// Stats computes the extended stats of the given fields, the fields using the object.property
+// notation are aggregated within a nested aggregation for their object path
 func (qb *queryBuilder) Stats(fields ...string) *queryBuilder {
 	qb.stats = &stats{Fields: fields}
 
@@ -132,7 +215,44 @@ func (qb *queryBuilder) From(value int) *queryBuilder {
 	return qb
 }
 
-func (qb *queryBuilder) WhereNested(field string, operand string, value interface{}) *queryBuilder {
+// Select restricts the returned _source to the given fields, wildcards such as "attributes.*" are supported
+func (qb *queryBuilder) Select(fields ...string) *queryBuilder {
+	if qb.sourceFilter == nil {
+		qb.sourceFilter = &sourceFilter{}
+	}
+
+	qb.sourceFilter.Includes = append(qb.sourceFilter.Includes, fields...)
+
+	return qb
+}
+
+// Exclude removes the given fields from the returned _source, wildcards such as "attributes.*" are supported
+func (qb *queryBuilder) Exclude(fields ...string) *queryBuilder {
+	if qb.sourceFilter == nil {
+		qb.sourceFilter = &sourceFilter{}
+	}
+
+	qb.sourceFilter.Excludes = append(qb.sourceFilter.Excludes, fields...)
+
+	return qb
+}
+
+// DocValueFields retrieves the doc values of the given fields, which are returned on each hit's fields
+func (qb *queryBuilder) DocValueFields(fields ...string) *queryBuilder {
+	qb.docValueFields = append(qb.docValueFields, fields...)
+
+	return qb
+}
+
+// StoredFields retrieves the given stored fields, which are returned on each hit's fields. Please
+// note that the _source is not returned by elasticsearch unless "_source" is part of the fields
+func (qb *queryBuilder) StoredFields(fields ...string) *queryBuilder {
+	qb.storedFields = append(qb.storedFields, fields...)
+
+	return qb
+}
+
+func (qb *queryBuilder) WhereNested(field string, operand string, value interface{}, options ...ClauseOption) *queryBuilder {
 	if len(qb.nested) == 0 {
 		qb.nested = map[string]*nested{}
 	}
@@ -151,12 +271,13 @@ func (qb *queryBuilder) WhereNested(field string, operand string, value interfac
 		Field:   field,
 		Operand: operand,
 		Value:   value,
+		Options: newClauseOptions(options),
 	})
 
 	return qb
 }
 
-func (qb *queryBuilder) WhereInNested(field string, values []interface{}) *queryBuilder {
+func (qb *queryBuilder) WhereInNested(field string, values []interface{}, options ...ClauseOption) *queryBuilder {
 	if len(qb.nested) == 0 {
 		qb.nested = map[string]*nested{}
 	}
@@ -172,14 +293,15 @@ func (qb *queryBuilder) WhereInNested(field string, values []interface{}) *query
 	}
 
 	qb.nested[path].whereIns = append(qb.nested[path].whereIns, &whereIn{
-		Field:  field,
-		Values: values,
+		Field:   field,
+		Values:  values,
+		Options: newClauseOptions(options),
 	})
 
 	return qb
 }
 
-func (qb *queryBuilder) WhereNotInNested(field string, values []interface{}) *queryBuilder {
+func (qb *queryBuilder) WhereNotInNested(field string, values []interface{}, options ...ClauseOption) *queryBuilder {
 	if len(qb.nested) == 0 {
 		qb.nested = map[string]*nested{}
 	}
@@ -195,14 +317,15 @@ func (qb *queryBuilder) WhereNotInNested(field string, values []interface{}) *qu
 	}
 
 	qb.nested[path].whereNotIns = append(qb.nested[path].whereNotIns, &whereNotIn{
-		Field:  field,
-		Values: values,
+		Field:   field,
+		Values:  values,
+		Options: newClauseOptions(options),
 	})
 
 	return qb
 }
 
-func (qb *queryBuilder) FilterNested(field string, operand string, value interface{}) *queryBuilder {
+func (qb *queryBuilder) FilterNested(field string, operand string, value interface{}, options ...ClauseOption) *queryBuilder {
 	if len(qb.nested) == 0 {
 		qb.nested = map[string]*nested{}
 	}
@@ -221,12 +344,13 @@ func (qb *queryBuilder) FilterNested(field string, operand string, value interfa
 		Field:   field,
 		Operand: operand,
 		Value:   value,
+		Options: newClauseOptions(options),
 	})
 
 	return qb
 }
 
-func (qb *queryBuilder) FilterInNested(field string, values []interface{}) *queryBuilder {
+func (qb *queryBuilder) FilterInNested(field string, values []interface{}, options ...ClauseOption) *queryBuilder {
 	if len(qb.nested) == 0 {
 		qb.nested = map[string]*nested{}
 	}
@@ -242,14 +366,15 @@ func (qb *queryBuilder) FilterInNested(field string, values []interface{}) *quer
 	}
 
 	qb.nested[path].filterIns = append(qb.nested[path].filterIns, &filterIn{
-		Field:  field,
-		Values: values,
+		Field:   field,
+		Values:  values,
+		Options: newClauseOptions(options),
 	})
 
 	return qb
 }
 
-func (qb *queryBuilder) MatchNested(field string, operand string, value interface{}) *queryBuilder {
+func (qb *queryBuilder) MatchNested(field string, operand string, value interface{}, options ...ClauseOption) *queryBuilder {
 	if len(qb.nested) == 0 {
 		qb.nested = map[string]*nested{}
 	}
@@ -268,28 +393,29 @@ func (qb *queryBuilder) MatchNested(field string, operand string, value interfac
 		Field:   field,
 		Operand: operand,
 		Value:   value,
+		Options: newClauseOptions(options),
 	})
 
 	return qb
 }
 
-func (qb *queryBuilder) MatchInNested(field string, values []interface{}) *queryBuilder {
+func (qb *queryBuilder) MatchInNested(field string, values []interface{}, options ...ClauseOption) *queryBuilder {
 	for _, value := range values {
-		qb.MatchNested(field, "=", value)
+		qb.MatchNested(field, "=", value, options...)
 	}
 
 	return qb
 }
 
-func (qb *queryBuilder) MatchNotInNested(field string, values []interface{}) *queryBuilder {
+func (qb *queryBuilder) MatchNotInNested(field string, values []interface{}, options ...ClauseOption) *queryBuilder {
 	for _, value := range values {
-		qb.MatchNested(field, "<>", value)
+		qb.MatchNested(field, "<>", value, options...)
 	}
 
 	return qb
 }
 
-func (qb *queryBuilder) MatchPhraseNested(field string, operand string, value interface{}) *queryBuilder {
+func (qb *queryBuilder) MatchPhraseNested(field string, operand string, value interface{}, options ...ClauseOption) *queryBuilder {
 	if len(qb.nested) == 0 {
 		qb.nested = map[string]*nested{}
 	}
@@ -308,22 +434,23 @@ func (qb *queryBuilder) MatchPhraseNested(field string, operand string, value in
 		Field:   field,
 		Operand: operand,
 		Value:   value,
+		Options: newClauseOptions(options),
 	})
 
 	return qb
 }
 
-func (qb *queryBuilder) MatchPhraseInNested(field string, values []interface{}) *queryBuilder {
+func (qb *queryBuilder) MatchPhraseInNested(field string, values []interface{}, options ...ClauseOption) *queryBuilder {
 	for _, value := range values {
-		qb.MatchPhraseNested(field, "=", value)
+		qb.MatchPhraseNested(field, "=", value, options...)
 	}
 
 	return qb
 }
 
-func (qb *queryBuilder) MatchPhraseNotInNested(field string, values []interface{}) *queryBuilder {
+func (qb *queryBuilder) MatchPhraseNotInNested(field string, values []interface{}, options ...ClauseOption) *queryBuilder {
 	for _, value := range values {
-		qb.MatchPhraseNested(field, "<>", value)
+		qb.MatchPhraseNested(field, "<>", value, options...)
 	}
 
 	return qb
@@ -341,6 +468,209 @@ func (qb *queryBuilder) OrderByNested(path string, order bool) *queryBuilder {
 	return qb
 }
 
+// WhereGeoDistance filters documents whose geo point is within the given distance (i.e. "10km") of the specified coordinates
+func (qb *queryBuilder) WhereGeoDistance(field string, lat float64, lon float64, distance string) *queryBuilder {
+	qb.geoDistances = append(qb.geoDistances, &geoDistance{
+		Field:    field,
+		Point:    GeoPoint{Lat: lat, Lon: lon},
+		Distance: distance,
+	})
+
+	return qb
+}
+
+// WhereGeoBoundingBox filters documents whose geo point falls within the given bounding box
+func (qb *queryBuilder) WhereGeoBoundingBox(field string, topLeft GeoPoint, bottomRight GeoPoint) *queryBuilder {
+	qb.geoBoundingBoxes = append(qb.geoBoundingBoxes, &geoBoundingBox{
+		Field:       field,
+		TopLeft:     topLeft,
+		BottomRight: bottomRight,
+	})
+
+	return qb
+}
+
+// WhereGeoPolygon filters documents whose geo point falls within the polygon described by the given points
+func (qb *queryBuilder) WhereGeoPolygon(field string, points ...GeoPoint) *queryBuilder {
+	qb.geoPolygons = append(qb.geoPolygons, &geoPolygon{Field: field, Points: points})
+
+	return qb
+}
+
+// WhereGeoShape filters documents whose geo shape has the given relation (intersects, disjoint, within
+// or contains) with the specified GeoJSON shape. An empty relation defaults to intersects
+func (qb *queryBuilder) WhereGeoShape(field string, shapeType string, coordinates interface{}, relation string) *queryBuilder {
+	qb.geoShapes = append(qb.geoShapes, &geoShape{
+		Field:       field,
+		Type:        shapeType,
+		Coordinates: coordinates,
+		Relation:    relation,
+	})
+
+	return qb
+}
+
+// OrderByGeoDistance sorts the documents by their distance to the given coordinates, closest first.
+// The computed distance is expressed in the given unit and reported on each hit returned by Search
+func (qb *queryBuilder) OrderByGeoDistance(field string, lat float64, lon float64, unit string) *queryBuilder {
+	qb.geoDistanceSort = &geoDistanceSort{
+		Field: field,
+		Point: GeoPoint{Lat: lat, Lon: lon},
+		Unit:  unit,
+	}
+
+	return qb
+}
+
+// WhereInLookup matches the documents whose field contains any of the values stored
+// under the given path of the specified document of another index (terms lookup)
+func (qb *queryBuilder) WhereInLookup(field string, index string, id string, path string, options ...ClauseOption) *queryBuilder {
+	qb.whereInLookups = append(qb.whereInLookups, &whereInLookup{
+		Field:   field,
+		Index:   index,
+		Id:      id,
+		Path:    path,
+		Options: newClauseOptions(options),
+	})
+
+	return qb
+}
+
+// WhereIds matches the documents with the given ids, it is much cheaper than WhereIn("id", ...)
+func (qb *queryBuilder) WhereIds(ids []string, options ...ClauseOption) *queryBuilder {
+	qb.whereIds = append(qb.whereIds, &whereIds{Values: ids, Options: newClauseOptions(options)})
+
+	return qb
+}
+
+// MoreLikeThis matches the documents that are similar to the given texts and documents, an
+// empty fields slice defaults to the index default fields and the options can be nil
+func (qb *queryBuilder) MoreLikeThis(
+	fields []string,
+	likeTexts []string,
+	likeDocIds []string,
+	options *MoreLikeThisOptions,
+	clauseOptions ...ClauseOption,
+) *queryBuilder {
+	qb.moreLikeThis = append(qb.moreLikeThis, &moreLikeThis{
+		Fields:        fields,
+		LikeTexts:     likeTexts,
+		LikeDocIds:    likeDocIds,
+		Options:       options,
+		ClauseOptions: newClauseOptions(clauseOptions),
+	})
+
+	return qb
+}
+
+// WhereHasChild matches the parent documents whose children of the given
+// join relation type match the clauses specified on the query callback
+func (qb *queryBuilder) WhereHasChild(childType string, query func(*Builder), options ...ClauseOption) *queryBuilder {
+	qb.hasChilds = append(qb.hasChilds, &hasChild{
+		Type:    childType,
+		Query:   joinQuery(query),
+		Options: newClauseOptions(options),
+	})
+
+	return qb
+}
+
+// WhereHasParent matches the child documents whose parent of the given
+// join relation type matches the clauses specified on the query callback
+func (qb *queryBuilder) WhereHasParent(parentType string, query func(*Builder), options ...ClauseOption) *queryBuilder {
+	qb.hasParents = append(qb.hasParents, &hasParent{
+		Type:    parentType,
+		Query:   joinQuery(query),
+		Options: newClauseOptions(options),
+	})
+
+	return qb
+}
+
+// WhereParentId matches the child documents of the given join relation type that belong to the specified parent
+func (qb *queryBuilder) WhereParentId(childType string, id string, options ...ClauseOption) *queryBuilder {
+	qb.parentIds = append(qb.parentIds, &parentId{
+		Type:    childType,
+		Id:      id,
+		Options: newClauseOptions(options),
+	})
+
+	return qb
+}
+
+// WhereGeoDistanceNested is the nested counterpart of WhereGeoDistance
+func (qb *queryBuilder) WhereGeoDistanceNested(field string, lat float64, lon float64, distance string) *queryBuilder {
+	nested := qb.nestedClauses(field)
+
+	nested.geoDistances = append(nested.geoDistances, &geoDistance{
+		Field:    field,
+		Point:    GeoPoint{Lat: lat, Lon: lon},
+		Distance: distance,
+	})
+
+	return qb
+}
+
+// WhereGeoBoundingBoxNested is the nested counterpart of WhereGeoBoundingBox
+func (qb *queryBuilder) WhereGeoBoundingBoxNested(field string, topLeft GeoPoint, bottomRight GeoPoint) *queryBuilder {
+	nested := qb.nestedClauses(field)
+
+	nested.geoBoundingBoxes = append(nested.geoBoundingBoxes, &geoBoundingBox{
+		Field:       field,
+		TopLeft:     topLeft,
+		BottomRight: bottomRight,
+	})
+
+	return qb
+}
+
+// WhereGeoPolygonNested is the nested counterpart of WhereGeoPolygon
+func (qb *queryBuilder) WhereGeoPolygonNested(field string, points ...GeoPoint) *queryBuilder {
+	nested := qb.nestedClauses(field)
+
+	nested.geoPolygons = append(nested.geoPolygons, &geoPolygon{Field: field, Points: points})
+
+	return qb
+}
+
+// WhereGeoShapeNested is the nested counterpart of WhereGeoShape
+func (qb *queryBuilder) WhereGeoShapeNested(field string, shapeType string, coordinates interface{}, relation string) *queryBuilder {
+	nested := qb.nestedClauses(field)
+
+	nested.geoShapes = append(nested.geoShapes, &geoShape{
+		Field:       field,
+		Type:        shapeType,
+		Coordinates: coordinates,
+		Relation:    relation,
+	})
+
+	return qb
+}
+
+// NestedInnerHits retrieves the nested objects that matched the nested clauses for the
+// given path, the inner hits are returned by Search under the path unless renamed
+func (qb *queryBuilder) NestedInnerHits(path string) *InnerHits {
+	nested := qb.nestedClauses(path)
+
+	nested.innerHits = &InnerHits{}
+
+	return nested.innerHits
+}
+
+func (qb *queryBuilder) nestedClauses(field string) *nested {
+	if len(qb.nested) == 0 {
+		qb.nested = map[string]*nested{}
+	}
+
+	path := strings.Split(field, ".")[0]
+
+	if _, valid := qb.nested[path]; !valid {
+		qb.nested[path] = &nested{}
+	}
+
+	return qb.nested[path]
+}
+
 func (qb *queryBuilder) Clear() *queryBuilder {
 	qb.wheres = nil
 	qb.matches = nil
@@ -356,6 +686,17 @@ func (qb *queryBuilder) Clear() *queryBuilder {
 	qb.from = nil
 	qb.nested = nil
 	qb.nestedSort = nil
+	qb.geoDistanceSort = nil
+	qb.geoClauses = geoClauses{}
+	qb.sourceFilter = nil
+	qb.docValueFields = nil
+	qb.storedFields = nil
+	qb.hasChilds = nil
+	qb.hasParents = nil
+	qb.parentIds = nil
+	qb.whereIds = nil
+	qb.moreLikeThis = nil
+	qb.whereInLookups = nil
 
 	return qb
 }
@@ -445,6 +786,26 @@ func (qb *queryBuilder) validateNestedClauses() error {
 				return errors.New("Wrong nested notation, needs to be 'object.property'")
 			}
 		}
+
+		if err := nested.geoClauses.validate(); err != nil {
+			return err
+		}
+
+		if nested.innerHits != nil {
+			if nested.isEmpty() {
+				return errors.New("Inner hits require at least one nested clause for path " + path)
+			}
+
+			if err := nested.innerHits.validate(); err != nil {
+				return err
+			}
+		}
+
+		for _, field := range nested.geoClauses.fields() {
+			if len(strings.Split(field, ".")) < 2 {
+				return errors.New("Wrong nested notation, needs to be 'object.property'")
+			}
+		}
 	}
 
 	return nil
@@ -627,5 +988,133 @@ func (qb *queryBuilder) validateMustClauses() error {
 		return err
 	}
 
+	if err := qb.validateGeoClauses(); err != nil {
+		return err
+	}
+
+	if err := qb.validateJoinClauses(); err != nil {
+		return err
+	}
+
+	if err := qb.validateDocumentClauses(); err != nil {
+		return err
+	}
+
 	return qb.validateNestedClauses()
 }
+
+func (qb *queryBuilder) validateDocumentClauses() error {
+	for _, whereIds := range qb.whereIds {
+		if err := whereIds.validate(); err != nil {
+			return err
+		}
+	}
+
+	for _, moreLikeThis := range qb.moreLikeThis {
+		if err := moreLikeThis.validate(); err != nil {
+			return err
+		}
+	}
+
+	for _, whereInLookup := range qb.whereInLookups {
+		if err := whereInLookup.validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (qb *queryBuilder) validateJoinClauses() error {
+	for _, hasChild := range qb.hasChilds {
+		if err := hasChild.validate(); err != nil {
+			return err
+		}
+	}
+
+	for _, hasParent := range qb.hasParents {
+		if err := hasParent.validate(); err != nil {
+			return err
+		}
+	}
+
+	for _, parentId := range qb.parentIds {
+		if err := parentId.validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (qb *queryBuilder) validateGeoClauses() error {
+	if qb.geoDistanceSort != nil {
+		if err := qb.geoDistanceSort.validate(); err != nil {
+			return err
+		}
+	}
+
+	return qb.geoClauses.validate()
+}
+
+func (gc *geoClauses) validate() error {
+	for _, geoDistance := range gc.geoDistances {
+		if err := geoDistance.validate(); err != nil {
+			return err
+		}
+	}
+
+	for _, geoBoundingBox := range gc.geoBoundingBoxes {
+		if err := geoBoundingBox.validate(); err != nil {
+			return err
+		}
+	}
+
+	for _, geoPolygon := range gc.geoPolygons {
+		if err := geoPolygon.validate(); err != nil {
+			return err
+		}
+	}
+
+	for _, geoShape := range gc.geoShapes {
+		if err := geoShape.validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (gc *geoClauses) fields() []string {
+	fields := []string{}
+
+	for _, geoDistance := range gc.geoDistances {
+		fields = append(fields, geoDistance.Field)
+	}
+
+	for _, geoBoundingBox := range gc.geoBoundingBoxes {
+		fields = append(fields, geoBoundingBox.Field)
+	}
+
+	for _, geoPolygon := range gc.geoPolygons {
+		fields = append(fields, geoPolygon.Field)
+	}
+
+	for _, geoShape := range gc.geoShapes {
+		fields = append(fields, geoShape.Field)
+	}
+
+	return fields
+}
+
+func joinQuery(query func(*Builder)) *Builder {
+	if query == nil {
+		return nil
+	}
+
+	builder := new(Builder)
+
+	query(builder)
+
+	return builder
+}
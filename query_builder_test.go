@@ -1,6 +1,9 @@
 package golastic
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestWheres(t *testing.T) {
 	builder := new(queryBuilder)
@@ -257,3 +260,326 @@ func TestFrom(t *testing.T) {
 		t.Error("Expected no errors but got ", got)
 	}
 }
+
+func TestGeoClauses(t *testing.T) {
+	builder := new(queryBuilder)
+	builder.WhereGeoDistance("location", 40.71, -74.0, "10km").
+		WhereGeoBoundingBox("location", GeoPoint{Lat: 41, Lon: -75}, GeoPoint{Lat: 40, Lon: -73}).
+		WhereGeoPolygon("location", GeoPoint{Lat: 40, Lon: -70}, GeoPoint{Lat: 30, Lon: -80}, GeoPoint{Lat: 20, Lon: -90}).
+		WhereGeoShape("area", "envelope", [][]float64{{13, 53}, {14, 52}}, "within").
+		OrderByGeoDistance("location", 40.71, -74.0, "km")
+
+	if got := builder.validateGeoClauses(); got != nil {
+		t.Error("Expected no errors but got ", got)
+	}
+
+	builder = new(queryBuilder)
+	builder.WhereGeoDistance("location", 100, -74.0, "10km")
+
+	if got := builder.validateGeoClauses(); got == nil {
+		t.Error("Expected errors but got ", got)
+	}
+
+	builder = new(queryBuilder)
+	builder.WhereGeoBoundingBox("location", GeoPoint{Lat: 40, Lon: -75}, GeoPoint{Lat: 41, Lon: -73})
+
+	if got := builder.validateGeoClauses(); got == nil {
+		t.Error("Expected errors but got ", got)
+	}
+
+	builder = new(queryBuilder)
+	builder.WhereGeoPolygon("location", GeoPoint{Lat: 40, Lon: -70}, GeoPoint{Lat: 30, Lon: -80})
+
+	if got := builder.validateGeoClauses(); got == nil {
+		t.Error("Expected errors but got ", got)
+	}
+
+	builder = new(queryBuilder)
+	builder.WhereGeoShape("area", "circle", [][]float64{{13, 53}, {14, 52}}, "")
+
+	if got := builder.validateGeoClauses(); got == nil {
+		t.Error("Expected errors but got ", got)
+	}
+
+	builder = new(queryBuilder)
+	builder.OrderByGeoDistance("location", 40.71, -74.0, "parsecs")
+
+	if got := builder.validateGeoClauses(); got == nil {
+		t.Error("Expected errors but got ", got)
+	}
+
+	builder = new(queryBuilder)
+	builder.WhereGeoDistanceNested("location", 40.71, -74.0, "10km")
+
+	if got := builder.validateNestedClauses(); got == nil {
+		t.Error("Expected errors but got ", got)
+	}
+}
+
+func TestClauseOptions(t *testing.T) {
+	builder := new(queryBuilder)
+	builder.Where("description", "=", "value1", Boost(2), Name("description_hit")).
+		Match("description", "=", "value2", Name("description_match")).
+		WhereIn("subject_id", []interface{}{1, 2}, Boost(0.5))
+
+	if got := builder.validateMustClauses(); got != nil {
+		t.Error("Expected no errors but got ", got)
+	}
+
+	source, err := (&Builder{queryBuilder: *builder}).query().Source()
+
+	if err != nil {
+		t.Error("Expected no errors but got ", err)
+	}
+
+	query, err := toJson(source)
+
+	if err != nil {
+		t.Error("Expected no errors but got ", err)
+	}
+
+	if !strings.Contains(query, `"_name":"description_hit"`) || !strings.Contains(query, `"boost":2`) {
+		t.Error("Expected the clause options to be part of the query but got ", query)
+	}
+
+	if !strings.Contains(query, `"_name":"description_match"`) || !strings.Contains(query, `"boost":0.5`) {
+		t.Error("Expected the clause options to be part of the query but got ", query)
+	}
+
+	builder = new(queryBuilder)
+	builder.Filter("subject_id", ">", 1, Name("recent")).
+		WhereNotIn("subject_id", []interface{}{3}, Name("not_third")).
+		MatchPhrase("description", "<>", "value3", Name("no_phrase"))
+
+	if got := builder.validateMustClauses(); got != nil {
+		t.Error("Expected no errors but got ", got)
+	}
+
+	source, err = (&Builder{queryBuilder: *builder}).query().Source()
+
+	if err != nil {
+		t.Error("Expected no errors but got ", err)
+	}
+
+	query, err = toJson(source)
+
+	if err != nil {
+		t.Error("Expected no errors but got ", err)
+	}
+
+	for _, name := range []string{"recent", "not_third", "no_phrase"} {
+		if !strings.Contains(query, `"_name":"`+name+`"`) {
+			t.Error("Expected the clause options to be part of the query but got ", query)
+		}
+	}
+
+	builder = new(queryBuilder)
+	builder.Filter("subject_id", ">", 1, Boost(-1))
+
+	if got := builder.validateMustClauses(); got == nil {
+		t.Error("Expected errors but got ", got)
+	}
+
+	builder = new(queryBuilder)
+	builder.MatchNested("attributes.sku", "=", "Red-31", Boost(-1))
+
+	if got := builder.validateMustClauses(); got == nil {
+		t.Error("Expected errors but got ", got)
+	}
+
+	values := []interface{}{1, 2}
+	invalid := []func(*queryBuilder){
+		func(qb *queryBuilder) { qb.WhereIn("subject_id", values, Boost(-1)) },
+		func(qb *queryBuilder) { qb.WhereNotIn("subject_id", values, Boost(-1)) },
+		func(qb *queryBuilder) { qb.FilterIn("subject_id", values, Boost(-1)) },
+		func(qb *queryBuilder) { qb.MatchIn("subject_id", values, Boost(-1)) },
+		func(qb *queryBuilder) { qb.MatchNotIn("subject_id", values, Boost(-1)) },
+		func(qb *queryBuilder) { qb.MatchPhraseIn("subject_id", values, Boost(-1)) },
+		func(qb *queryBuilder) { qb.MatchPhraseNotIn("subject_id", values, Boost(-1)) },
+		func(qb *queryBuilder) { qb.WhereInNested("attributes.size", values, Boost(-1)) },
+		func(qb *queryBuilder) { qb.WhereNotInNested("attributes.size", values, Boost(-1)) },
+		func(qb *queryBuilder) { qb.FilterInNested("attributes.size", values, Boost(-1)) },
+		func(qb *queryBuilder) { qb.MatchInNested("attributes.size", values, Boost(-1)) },
+		func(qb *queryBuilder) { qb.MatchNotInNested("attributes.size", values, Boost(-1)) },
+		func(qb *queryBuilder) { qb.MatchPhraseInNested("attributes.size", values, Boost(-1)) },
+		func(qb *queryBuilder) { qb.MatchPhraseNotInNested("attributes.size", values, Boost(-1)) },
+		func(qb *queryBuilder) { qb.FilterIn("", values) },
+		func(qb *queryBuilder) { qb.Filter("subject_id", ">", 1, Boost(2)) },
+		func(qb *queryBuilder) { qb.FilterIn("subject_id", values, Boost(2)) },
+		func(qb *queryBuilder) { qb.FilterNested("attributes.size", "=", 1, Boost(2)) },
+		func(qb *queryBuilder) { qb.Where("subject_id", "<>", 1, Boost(2)) },
+		func(qb *queryBuilder) { qb.WhereNotIn("subject_id", values, Boost(2)) },
+		func(qb *queryBuilder) { qb.Match("description", "<>", "value", Boost(2)) },
+		func(qb *queryBuilder) { qb.MatchNotIn("description", values, Boost(2)) },
+		func(qb *queryBuilder) { qb.MatchPhrase("description", "<>", "value", Boost(2)) },
+		func(qb *queryBuilder) { qb.MatchPhraseNotIn("description", values, Boost(2)) },
+	}
+
+	for _, clause := range invalid {
+		builder = new(queryBuilder)
+		clause(builder)
+
+		if got := builder.validateMustClauses(); got == nil {
+			t.Error("Expected errors but got ", got)
+		}
+	}
+}
+
+func TestJoinClauses(t *testing.T) {
+	builder := new(queryBuilder)
+	builder.WhereHasChild("line", func(q *Builder) {
+		q.Where("sku", "=", "Red-31")
+	}, Name("has_red_line")).
+		WhereHasParent("order", nil).
+		WhereParentId("line", "order-1")
+
+	if got := builder.validateMustClauses(); got != nil {
+		t.Error("Expected no errors but got ", got)
+	}
+
+	source, err := (&Builder{queryBuilder: *builder}).query().Source()
+
+	if err != nil {
+		t.Error("Expected no errors but got ", err)
+	}
+
+	query, err := toJson(source)
+
+	if err != nil {
+		t.Error("Expected no errors but got ", err)
+	}
+
+	if !strings.Contains(query, `"has_child"`) || !strings.Contains(query, `"has_parent"`) || !strings.Contains(query, `"parent_id"`) {
+		t.Error("Expected the join clauses to be part of the query but got ", query)
+	}
+
+	builder = new(queryBuilder)
+	builder.WhereHasChild("line", func(q *Builder) {
+		q.Where("quantity", ">", "many")
+	})
+
+	if got := builder.validateMustClauses(); got == nil {
+		t.Error("Expected errors but got ", got)
+	}
+
+	builder = new(queryBuilder)
+	builder.WhereParentId("line", "")
+
+	if got := builder.validateMustClauses(); got == nil {
+		t.Error("Expected errors but got ", got)
+	}
+
+	relation := &joinRelation{Field: "relation", Name: "line", Parent: "order-1"}
+
+	if got := relation.validate(); got != nil {
+		t.Error("Expected no errors but got ", got)
+	}
+
+	if value, valid := relation.value().(map[string]interface{}); !valid || value["parent"] != "order-1" {
+		t.Error("Expected the join value to reference the parent but got ", relation.value())
+	}
+
+	child := new(Builder).JoinRelation("relation", "line", "order-1")
+
+	for _, routing := range []string{"", "order-1"} {
+		if got, err := child.Routing(routing).documentRouting(map[string]interface{}{}); err != nil || got != "order-1" {
+			t.Error("Expected the child to be routed to its parent but got ", got, err)
+		}
+	}
+
+	if _, got := child.Routing("order-2").documentRouting(map[string]interface{}{}); got == nil {
+		t.Error("Expected errors but got ", got)
+	}
+
+	child.Routing("").RouteBy(RoutingField("order_id"))
+
+	if got, err := child.documentRouting(map[string]interface{}{"order_id": "order-1"}); err != nil || got != "order-1" {
+		t.Error("Expected the child to be routed to its parent but got ", got, err)
+	}
+
+	if _, got := child.documentRouting(map[string]interface{}{"order_id": "order-2"}); got == nil {
+		t.Error("Expected errors but got ", got)
+	}
+}
+
+func TestDocumentClauses(t *testing.T) {
+	builder := new(queryBuilder)
+	builder.WhereIds([]string{"1", "2", "3"}).
+		MoreLikeThis([]string{"title", "description"}, []string{"wizard school"}, []string{"4"}, &MoreLikeThisOptions{
+			MinTermFreq:        1,
+			MaxQueryTerms:      12,
+			MinimumShouldMatch: "30%",
+		})
+
+	if got := builder.validateMustClauses(); got != nil {
+		t.Error("Expected no errors but got ", got)
+	}
+
+	source, err := (&Builder{queryBuilder: *builder}).query().Source()
+
+	if err != nil {
+		t.Error("Expected no errors but got ", err)
+	}
+
+	query, err := toJson(source)
+
+	if err != nil {
+		t.Error("Expected no errors but got ", err)
+	}
+
+	if !strings.Contains(query, `"ids":{"values":["1","2","3"]}`) || !strings.Contains(query, `"minimum_should_match":"30%"`) {
+		t.Error("Expected the ids and more like this clauses to be part of the query but got ", query)
+	}
+
+	builder = new(queryBuilder)
+	builder.WhereIds([]string{"5"}, Name("by_id")).
+		MoreLikeThis(nil, []string{"wizard school"}, nil, nil, Boost(2), Name("similar"))
+
+	if got := builder.validateMustClauses(); got != nil {
+		t.Error("Expected no errors but got ", got)
+	}
+
+	source, err = (&Builder{queryBuilder: *builder}).query().Source()
+
+	if err != nil {
+		t.Error("Expected no errors but got ", err)
+	}
+
+	query, err = toJson(source)
+
+	if err != nil {
+		t.Error("Expected no errors but got ", err)
+	}
+
+	if !strings.Contains(query, `"_name":"by_id"`) || !strings.Contains(query, `"_name":"similar"`) || !strings.Contains(query, `"boost":2`) {
+		t.Error("Expected the clause options to be part of the query but got ", query)
+	}
+
+	builder = new(queryBuilder)
+	builder.WhereIds(nil)
+
+	if got := builder.validateMustClauses(); got == nil {
+		t.Error("Expected errors but got ", got)
+	}
+
+	builder = new(queryBuilder)
+	builder.MoreLikeThis([]string{"title"}, nil, nil, nil)
+
+	if got := builder.validateMustClauses(); got == nil {
+		t.Error("Expected errors but got ", got)
+	}
+
+	builder = new(queryBuilder)
+	builder.MoreLikeThis(nil, []string{"wizard school"}, nil, &MoreLikeThisOptions{MaxQueryTerms: -1})
+
+	if got := builder.validateMustClauses(); got == nil {
+		t.Error("Expected errors but got ", got)
+	}
+
+	builder = new(queryBuilder)
+	builder.WhereIds([]string{"5"}, Boost(-1))
+
+	if got := builder.validateMustClauses(); got == nil {
+		t.Error("Expected errors but got ", got)
+	}
+}
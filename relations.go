@@ -0,0 +1,192 @@
+package golastic
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/Jeffail/gabs"
+	elastic "github.com/alejandro-carstens/elasticfork"
+)
+
+// Relation describes the documents of a related index to be eager loaded by Get. The related
+// documents whose foreign key matches the local key of a result get attached to it under Name
+type Relation struct {
+	// Name is the attribute the related documents are attached to
+	Name string
+	// Index is the index holding the related documents
+	Index string
+	// LocalKey is the field of the results holding the key, or keys, of the related documents
+	LocalKey string
+	// ForeignKey is the field of the related documents matched against the local key,
+	// when empty the local key is matched against the id of the related documents
+	ForeignKey string
+}
+
+func (r *Relation) validate() error {
+	if len(r.Name) == 0 {
+		return errors.New("name cannot be empty")
+	}
+
+	if len(r.Index) == 0 {
+		return errors.New("index cannot be empty")
+	}
+
+	if len(r.LocalKey) == 0 {
+		return errors.New("local key cannot be empty")
+	}
+
+	return nil
+}
+
+func (r *Relation) matchesIds() bool {
+	return len(r.ForeignKey) == 0 || r.ForeignKey == "_id"
+}
+
+func (r *Relation) query(keys []interface{}) elastic.Query {
+	if !r.matchesIds() {
+		return elastic.NewTermsQuery(r.ForeignKey, keys...)
+	}
+
+	ids := []string{}
+
+	for _, key := range keys {
+		ids = append(ids, fmt.Sprint(key))
+	}
+
+	return elastic.NewIdsQuery().Ids(ids...)
+}
+
+// With eager loads the documents of the given related indices, after retrieving the results Get scrolls
+// through a single ids or terms query per relation and attaches the related documents to each result
+func (b *Builder) With(relations ...Relation) *Builder {
+	for i := range relations {
+		b.relations = append(b.relations, &relations[i])
+	}
+
+	return b
+}
+
+func (b *Builder) loadRelations(sources []*json.RawMessage, items interface{}) error {
+	docs := []*gabs.Container{}
+
+	for _, source := range sources {
+		doc, err := parseSource(*source)
+
+		if err != nil {
+			return err
+		}
+
+		docs = append(docs, doc)
+	}
+
+	for _, relation := range b.relations {
+		if err := relation.validate(); err != nil {
+			return err
+		}
+
+		if err := b.loadRelation(relation, docs); err != nil {
+			return err
+		}
+	}
+
+	results := []interface{}{}
+
+	for _, doc := range docs {
+		results = append(results, doc.Data())
+	}
+
+	data, err := json.Marshal(results)
+
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, items)
+}
+
+func (b *Builder) loadRelation(relation *Relation, docs []*gabs.Container) error {
+	keys := []interface{}{}
+	seen := map[string]bool{}
+
+	for _, doc := range docs {
+		for _, key := range relationKeys(doc, relation.LocalKey) {
+			if seen[fmt.Sprint(key)] {
+				continue
+			}
+
+			seen[fmt.Sprint(key)] = true
+			keys = append(keys, key)
+		}
+	}
+
+	related := map[string][]json.RawMessage{}
+
+	if len(keys) > 0 {
+		scroll := b.client.Scroll(relation.Index).Query(relation.query(keys)).Size(LIMIT)
+
+		err := scrollHits(b.context, scroll, func(hits []*elastic.SearchHit) error {
+			for _, hit := range hits {
+				if relation.matchesIds() {
+					related[hit.Id] = append(related[hit.Id], hit.Source)
+
+					continue
+				}
+
+				source, err := parseSource(hit.Source)
+
+				if err != nil {
+					return err
+				}
+
+				for _, key := range relationKeys(source, relation.ForeignKey) {
+					related[fmt.Sprint(key)] = append(related[fmt.Sprint(key)], hit.Source)
+				}
+			}
+
+			return nil
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, doc := range docs {
+		documents := []json.RawMessage{}
+
+		for _, key := range relationKeys(doc, relation.LocalKey) {
+			documents = append(documents, related[fmt.Sprint(key)]...)
+		}
+
+		if _, err := doc.SetP(documents, relation.Name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func relationKeys(doc *gabs.Container, path string) []interface{} {
+	value := doc.Path(path).Data()
+
+	if value == nil {
+		return nil
+	}
+
+	if values, valid := value.([]interface{}); valid {
+		return values
+	}
+
+	return []interface{}{value}
+}
+
+// parseSource preserves numeric values as json.Number so that
+// they keep their exact representation when being compared
+func parseSource(source json.RawMessage) (*gabs.Container, error) {
+	decoder := json.NewDecoder(bytes.NewReader(source))
+	decoder.UseNumber()
+
+	return gabs.ParseJSONDecoder(decoder)
+}
@@ -0,0 +1,57 @@
+package golastic
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelationValidation(t *testing.T) {
+	relation := &Relation{Name: "followed", Index: "accounts", LocalKey: "followed_ids"}
+
+	if got := relation.validate(); got != nil {
+		t.Error("Expected no errors but got ", got)
+	}
+
+	invalid := []*Relation{
+		{Index: "accounts", LocalKey: "followed_ids"},
+		{Name: "followed", LocalKey: "followed_ids"},
+		{Name: "followed", Index: "accounts"},
+	}
+
+	for _, relation := range invalid {
+		if got := relation.validate(); got == nil {
+			t.Error("Expected errors but got ", got)
+		}
+	}
+}
+
+func TestRelationQuery(t *testing.T) {
+	doc, err := parseSource(json.RawMessage(`{"account_id":12345678901,"followed_ids":[1,2]}`))
+
+	assert.Nil(t, err)
+
+	relation := &Relation{Name: "followed", Index: "accounts", LocalKey: "followed_ids"}
+
+	source, err := relation.query(relationKeys(doc, relation.LocalKey)).Source()
+
+	assert.Nil(t, err)
+
+	query, err := toJson(source)
+
+	assert.Nil(t, err)
+	assert.Equal(t, `{"ids":{"values":["1","2"]}}`, query)
+
+	relation = &Relation{Name: "account", Index: "accounts", LocalKey: "account_id", ForeignKey: "account_id"}
+
+	source, err = relation.query(relationKeys(doc, relation.LocalKey)).Source()
+
+	assert.Nil(t, err)
+
+	query, err = toJson(source)
+
+	assert.Nil(t, err)
+	assert.Equal(t, `{"terms":{"account_id":[12345678901]}}`, query)
+	assert.Nil(t, relationKeys(doc, "missing"))
+}
@@ -1,6 +1,12 @@
 package golastic
 
-import "github.com/Jeffail/gabs"
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/Jeffail/gabs"
+)
 
 // AggregationResponses represents a map for *AggregationResponse
 type AggregationResponses map[string]*AggregationResponse
@@ -25,9 +31,19 @@ func (mmr *MinMaxResponse) ToGabsContainer() (*gabs.Container, error) {
 
 // AggregationResponse represents an aggregation's query response
 type AggregationResponse struct {
-	DocCountErrorUpperBound int                  `json:"doc_count_error_upper_bound"`
-	SumOtherDocCount        int                  `json:"sum_other_doc_count"`
-	Buckets                 []*AggregationBucket `json:"buckets"`
+	DocCountErrorUpperBound int                    `json:"doc_count_error_upper_bound"`
+	SumOtherDocCount        int                    `json:"sum_other_doc_count"`
+	Buckets                 []*AggregationBucket   `json:"buckets"`
+	Value                   *float64               `json:"value,omitempty"`
+	ValueAsString           string                 `json:"value_as_string,omitempty"`
+	Values                  map[string]*float64    `json:"values,omitempty"`
+	Keys                    []interface{}          `json:"keys,omitempty"`
+	DocCount                *int                   `json:"doc_count,omitempty"`
+	Hits                    []json.RawMessage      `json:"hits,omitempty"`
+	Bounds                  *GeoBounds             `json:"bounds,omitempty"`
+	Centroid                *GeoPoint              `json:"centroid,omitempty"`
+	Stats                   *ExtendedStatsResponse `json:"stats,omitempty"`
+	Items                   AggregationResponses   `json:"items,omitempty"`
 }
 
 // ToGabsContainer converts a response to a *gabs.Container instance
@@ -35,9 +51,215 @@ func (ab *AggregationResponse) ToGabsContainer() (*gabs.Container, error) {
 	return toGabsContainer(ab)
 }
 
+// Decode decodes the hits returned by a top_hits aggregation into items
+func (ab *AggregationResponse) Decode(items interface{}) error {
+	results, err := toJson(ab.Hits)
+
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal([]byte(results), items)
+}
+
 // AggregationBucket represents a bucket within an AggregationResponse
 type AggregationBucket struct {
-	Key      interface{}                     `json:"key"`
-	DocCount int                             `json:"doc_count"`
-	Items    map[string]*AggregationResponse `json:"items"`
+	Key            interface{}                     `json:"key"`
+	KeyAsString    string                          `json:"key_as_string,omitempty"`
+	DocCount       int                             `json:"doc_count"`
+	ParentDocCount *int                            `json:"parent_doc_count,omitempty"`
+	From           *float64                        `json:"from,omitempty"`
+	To             *float64                        `json:"to,omitempty"`
+	Items          map[string]*AggregationResponse `json:"items"`
+}
+
+// ExtendedStatsResponse represents the result of a stats or extended_stats aggregation,
+// the values are nil when no document has a value for the aggregated field
+type ExtendedStatsResponse struct {
+	Count              int64               `json:"count"`
+	Min                *float64            `json:"min"`
+	Max                *float64            `json:"max"`
+	Avg                *float64            `json:"avg"`
+	Sum                *float64            `json:"sum"`
+	SumOfSquares       *float64            `json:"sum_of_squares,omitempty"`
+	Variance           *float64            `json:"variance,omitempty"`
+	StdDeviation       *float64            `json:"std_deviation,omitempty"`
+	StdDeviationBounds *StdDeviationBounds `json:"std_deviation_bounds,omitempty"`
+}
+
+// ToGabsContainer converts a response to a *gabs.Container instance
+func (esr *ExtendedStatsResponse) ToGabsContainer() (*gabs.Container, error) {
+	return toGabsContainer(esr)
+}
+
+// StdDeviationBounds represents the interval of plus/minus two standard deviations from the average
+type StdDeviationBounds struct {
+	Upper *float64 `json:"upper"`
+	Lower *float64 `json:"lower"`
+}
+
+// CompositeResponse represents a page of buckets of a composite aggregation,
+// the AfterKey is used for retrieving the following page
+type CompositeResponse struct {
+	AfterKey map[string]interface{} `json:"after_key"`
+	Buckets  []*CompositeBucket     `json:"buckets"`
+}
+
+// ToGabsContainer converts a response to a *gabs.Container instance
+func (cr *CompositeResponse) ToGabsContainer() (*gabs.Container, error) {
+	return toGabsContainer(cr)
+}
+
+// CompositeBucket represents a bucket within a CompositeResponse keyed by the value of each source
+type CompositeBucket struct {
+	Key      map[string]interface{} `json:"key"`
+	DocCount int                    `json:"doc_count"`
+	Items    AggregationResponses   `json:"items"`
+}
+
+// TimeSeriesResponse represents an ordered series of points, the key holds
+// the value of the split by field and is nil when the series is not split
+type TimeSeriesResponse struct {
+	Key      interface{}        `json:"key"`
+	DocCount int                `json:"doc_count"`
+	Points   []*TimeSeriesPoint `json:"points"`
+}
+
+// ToGabsContainer converts a response to a *gabs.Container instance
+func (tsr *TimeSeriesResponse) ToGabsContainer() (*gabs.Container, error) {
+	return toGabsContainer(tsr)
+}
+
+// TimeSeriesPoint represents the count and metrics of the documents within an interval
+// starting at the timestamp, points inserted by gap filling have no key as string
+type TimeSeriesPoint struct {
+	Timestamp   time.Time            `json:"timestamp"`
+	KeyAsString string               `json:"key_as_string,omitempty"`
+	Count       int                  `json:"count"`
+	Metrics     AggregationResponses `json:"metrics"`
+}
+
+// GeoBounds represents the bounding box returned by a geo_bounds aggregation
+type GeoBounds struct {
+	TopLeft     GeoPoint `json:"top_left"`
+	BottomRight GeoPoint `json:"bottom_right"`
+}
+
+// SearchResponse represents the metadata of the hits returned by a search
+type SearchResponse struct {
+	TotalHits   int64            `json:"total_hits"`
+	MaxScore    *float64         `json:"max_score"`
+	Hits        []*SearchHit     `json:"hits"`
+	Suggestions SuggestResponses `json:"suggestions,omitempty"`
+	Facets      []*FacetResponse `json:"facets,omitempty"`
+}
+
+// ToGabsContainer converts a response to a *gabs.Container instance
+func (sr *SearchResponse) ToGabsContainer() (*gabs.Container, error) {
+	return toGabsContainer(sr)
+}
+
+// FacetResponse represents the values of a facet field, the count of each value ignores
+// the selections of the facet itself so that every value can still be selected
+type FacetResponse struct {
+	Field  string        `json:"field"`
+	Values []*FacetValue `json:"values"`
+}
+
+// FacetValue represents a value of a facet along with its count and whether it is selected,
+// selected values without matching documents are included with a count of 0
+type FacetValue struct {
+	Value    interface{} `json:"value"`
+	Count    int         `json:"count"`
+	Selected bool        `json:"selected"`
+}
+
+// SearchHit represents the metadata of a single hit, the decoded
+// source for the hit is found at the same position in the search results
+type SearchHit struct {
+	Id             string                        `json:"id"`
+	Index          string                        `json:"index"`
+	Score          *float64                      `json:"score"`
+	Sort           []interface{}                 `json:"sort"`
+	Distance       *float64                      `json:"distance,omitempty"`
+	MatchedQueries []string                      `json:"matched_queries,omitempty"`
+	Highlight      map[string][]string           `json:"highlight,omitempty"`
+	Fields         map[string]interface{}        `json:"fields,omitempty"`
+	InnerHits      map[string]*InnerHitsResponse `json:"inner_hits,omitempty"`
+}
+
+// InnerHitsResponse represents the inner hits returned for a collapsed field or a nested query
+type InnerHitsResponse struct {
+	TotalHits int64        `json:"total_hits"`
+	Hits      []*SearchHit `json:"hits"`
+	sources   []json.RawMessage
+}
+
+// Decode decodes the sources of the inner hits into items
+func (ihr *InnerHitsResponse) Decode(items interface{}) error {
+	results, err := toJson(ihr.sources)
+
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal([]byte(results), items)
+}
+
+// SuggestResponses maps each suggester name to its suggestions
+type SuggestResponses map[string][]*SuggestResponse
+
+// ToGabsContainer converts a response to a *gabs.Container instance
+func (sr *SuggestResponses) ToGabsContainer() (*gabs.Container, error) {
+	return toGabsContainer(sr)
+}
+
+// SuggestResponse represents the suggestions for a term of the suggested text
+type SuggestResponse struct {
+	Text    string           `json:"text"`
+	Offset  int              `json:"offset"`
+	Length  int              `json:"length"`
+	Options []*SuggestOption `json:"options"`
+}
+
+// SuggestOption represents a suggested option, completion suggester
+// options also include the suggested document
+type SuggestOption struct {
+	Text         string          `json:"text"`
+	Score        float64         `json:"score"`
+	Freq         int             `json:"freq,omitempty"`
+	Highlighted  string          `json:"highlighted,omitempty"`
+	CollateMatch bool            `json:"collate_match,omitempty"`
+	Id           string          `json:"id,omitempty"`
+	Index        string          `json:"index,omitempty"`
+	Source       json.RawMessage `json:"source,omitempty"`
+}
+
+// Decode decodes the suggested document of a completion suggester option into item
+func (so *SuggestOption) Decode(item interface{}) error {
+	if len(so.Source) == 0 {
+		return errors.New("No document found for option " + so.Text)
+	}
+
+	return json.Unmarshal(so.Source, item)
+}
+
+// FindManyResponse reports which of the requested documents were found, which were missing
+// and which could not be retrieved, i.e. because their index does not exist
+type FindManyResponse struct {
+	Found   []*DocumentId    `json:"found"`
+	Missing []*DocumentId    `json:"missing"`
+	Errors  []*DocumentError `json:"errors"`
+}
+
+// ToGabsContainer converts a response to a *gabs.Container instance
+func (fmr *FindManyResponse) ToGabsContainer() (*gabs.Container, error) {
+	return toGabsContainer(fmr)
+}
+
+// DocumentError represents the failure to retrieve a document
+type DocumentError struct {
+	Document *DocumentId `json:"document"`
+	Type     string      `json:"type"`
+	Reason   string      `json:"reason"`
 }
@@ -0,0 +1,315 @@
+package golastic
+
+import (
+	"errors"
+
+	elastic "github.com/alejandro-carstens/elasticfork"
+)
+
+// Score represents the struct in charge of building function_score
+// queries in order to tune the relevance of the returned hits
+type Score struct {
+	functions []*scoreFunction
+	scoreMode string
+	boostMode string
+	maxBoost  *float64
+	minScore  *float64
+	boost     *float64
+}
+
+// FieldValueFactor uses the value of a numeric field to influence the score. The missing value, if not nil,
+// is used for documents that do not have the field, otherwise those documents fail to be scored. Valid
+// modifiers are none, log, log1p, log2p, ln, ln1p, ln2p, square, sqrt and reciprocal
+func (s *Score) FieldValueFactor(field string, factor float64, modifier string, missing *float64) *Score {
+	s.functions = append(s.functions, &scoreFunction{
+		Type:     "field_value_factor",
+		Field:    field,
+		Factor:   factor,
+		Modifier: modifier,
+		Missing:  missing,
+	})
+
+	return s
+}
+
+// Gauss applies a gauss decay function on a date, numeric or geo point field. A
+// GeoPoint can be passed as the origin for geo point fields. A nil offset and a
+// 0 decay fallback to the elasticsearch defaults
+func (s *Score) Gauss(field string, origin interface{}, scale interface{}, offset interface{}, decay float64) *Score {
+	return s.decay("gauss", field, origin, scale, offset, decay)
+}
+
+// Linear applies a linear decay function on a date, numeric or geo point field
+func (s *Score) Linear(field string, origin interface{}, scale interface{}, offset interface{}, decay float64) *Score {
+	return s.decay("linear", field, origin, scale, offset, decay)
+}
+
+// Exp applies an exponential decay function on a date, numeric or geo point field
+func (s *Score) Exp(field string, origin interface{}, scale interface{}, offset interface{}, decay float64) *Score {
+	return s.decay("exp", field, origin, scale, offset, decay)
+}
+
+// Weight multiplies the score of the documents matching the clauses
+// specified on the filter callback by the given weight
+func (s *Score) Weight(weight float64, filter func(*Builder)) *Score {
+	function := &scoreFunction{Type: "weight", Weight: weight}
+
+	if filter != nil {
+		function.Filter = new(Builder)
+
+		filter(function.Filter)
+	}
+
+	s.functions = append(s.functions, function)
+
+	return s
+}
+
+// ScriptScore computes the score with the given painless script
+func (s *Score) ScriptScore(script string, params map[string]interface{}) *Score {
+	s.functions = append(s.functions, &scoreFunction{
+		Type:   "script_score",
+		Script: script,
+		Params: params,
+	})
+
+	return s
+}
+
+// RandomScore generates uniformly distributed random scores, the same seed
+// will always yield the same scores making it suitable for stable random sampling
+func (s *Score) RandomScore(seed interface{}) *Score {
+	s.functions = append(s.functions, &scoreFunction{
+		Type: "random_score",
+		Seed: seed,
+	})
+
+	return s
+}
+
+// ScoreMode sets how the computed scores are combined (multiply, sum, avg, first, max or min)
+func (s *Score) ScoreMode(mode string) *Score {
+	s.scoreMode = mode
+
+	return s
+}
+
+// BoostMode sets how the computed score is combined with the score
+// of the query (multiply, replace, sum, avg, max or min)
+func (s *Score) BoostMode(mode string) *Score {
+	s.boostMode = mode
+
+	return s
+}
+
+// MaxBoost restricts the new score to not exceed the provided limit
+func (s *Score) MaxBoost(maxBoost float64) *Score {
+	s.maxBoost = &maxBoost
+
+	return s
+}
+
+// MinScore excludes the documents that do not meet the provided score threshold
+func (s *Score) MinScore(minScore float64) *Score {
+	s.minScore = &minScore
+
+	return s
+}
+
+// Boost sets the boost for the whole function_score query
+func (s *Score) Boost(boost float64) *Score {
+	s.boost = &boost
+
+	return s
+}
+
+func (s *Score) decay(decayType string, field string, origin interface{}, scale interface{}, offset interface{}, decay float64) *Score {
+	s.functions = append(s.functions, &scoreFunction{
+		Type:   decayType,
+		Field:  field,
+		Origin: origin,
+		Scale:  scale,
+		Offset: offset,
+		Decay:  decay,
+	})
+
+	return s
+}
+
+func (s *Score) validate() error {
+	if len(s.scoreMode) > 0 && !inSlice(s.scoreMode, "multiply", "sum", "avg", "first", "max", "min") {
+		return errors.New("The score mode is invalid.")
+	}
+
+	if len(s.boostMode) > 0 && !inSlice(s.boostMode, "multiply", "replace", "sum", "avg", "max", "min") {
+		return errors.New("The boost mode is invalid.")
+	}
+
+	for _, function := range s.functions {
+		if err := function.validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Score) query(query elastic.Query) *elastic.FunctionScoreQuery {
+	functionScoreQuery := elastic.NewFunctionScoreQuery().Query(query)
+
+	for _, function := range s.functions {
+		if function.Filter != nil {
+			functionScoreQuery = functionScoreQuery.Add(function.Filter.query(), function.scoreFunction())
+			continue
+		}
+
+		functionScoreQuery = functionScoreQuery.AddScoreFunc(function.scoreFunction())
+	}
+
+	if len(s.scoreMode) > 0 {
+		functionScoreQuery = functionScoreQuery.ScoreMode(s.scoreMode)
+	}
+
+	if len(s.boostMode) > 0 {
+		functionScoreQuery = functionScoreQuery.BoostMode(s.boostMode)
+	}
+
+	if s.maxBoost != nil {
+		functionScoreQuery = functionScoreQuery.MaxBoost(*s.maxBoost)
+	}
+
+	if s.minScore != nil {
+		functionScoreQuery = functionScoreQuery.MinScore(*s.minScore)
+	}
+
+	if s.boost != nil {
+		functionScoreQuery = functionScoreQuery.Boost(*s.boost)
+	}
+
+	return functionScoreQuery
+}
+
+type scoreFunction struct {
+	Type     string
+	Field    string
+	Origin   interface{}
+	Scale    interface{}
+	Offset   interface{}
+	Decay    float64
+	Factor   float64
+	Modifier string
+	Missing  *float64
+	Weight   float64
+	Filter   *Builder
+	Script   string
+	Params   map[string]interface{}
+	Seed     interface{}
+}
+
+func (sf *scoreFunction) validate() error {
+	switch sf.Type {
+	case "field_value_factor":
+		if len(sf.Field) == 0 {
+			return errors.New("field cannot be empty")
+		}
+
+		modifiers := []string{"", "none", "log", "log1p", "log2p", "ln", "ln1p", "ln2p", "square", "sqrt", "reciprocal"}
+
+		if !inSlice(sf.Modifier, modifiers...) {
+			return errors.New("The field value factor modifier is invalid.")
+		}
+	case "gauss", "linear", "exp":
+		if len(sf.Field) == 0 {
+			return errors.New("field cannot be empty")
+		}
+
+		if sf.Origin == nil || sf.Scale == nil {
+			return errors.New("Decay functions require an origin and a scale.")
+		}
+
+		if sf.Decay < 0 || sf.Decay >= 1 {
+			return errors.New("The decay needs to be between 0 and 1.")
+		}
+
+		if point, valid := sf.Origin.(GeoPoint); valid {
+			return point.validate()
+		}
+	case "weight":
+		if sf.Weight <= 0 {
+			return errors.New("The weight needs to be greater than 0.")
+		}
+
+		if sf.Filter != nil {
+			return sf.Filter.validateMustClauses()
+		}
+	case "script_score":
+		if len(sf.Script) == 0 {
+			return errors.New("script cannot be empty")
+		}
+	case "random_score":
+		if sf.Seed == nil {
+			return errors.New("seed cannot be empty")
+		}
+	}
+
+	return nil
+}
+
+func (sf *scoreFunction) scoreFunction() elastic.ScoreFunction {
+	switch sf.Type {
+	case "field_value_factor":
+		function := elastic.NewFieldValueFactorFunction().Field(sf.Field).Factor(sf.Factor)
+
+		if sf.Missing != nil {
+			function = function.Missing(*sf.Missing)
+		}
+
+		if len(sf.Modifier) > 0 {
+			function = function.Modifier(sf.Modifier)
+		}
+
+		return function
+	case "gauss":
+		function := elastic.NewGaussDecayFunction().FieldName(sf.Field).Origin(sf.Origin).Scale(sf.Scale)
+
+		if sf.Offset != nil {
+			function = function.Offset(sf.Offset)
+		}
+
+		if sf.Decay > 0 {
+			function = function.Decay(sf.Decay)
+		}
+
+		return function
+	case "linear":
+		function := elastic.NewLinearDecayFunction().FieldName(sf.Field).Origin(sf.Origin).Scale(sf.Scale)
+
+		if sf.Offset != nil {
+			function = function.Offset(sf.Offset)
+		}
+
+		if sf.Decay > 0 {
+			function = function.Decay(sf.Decay)
+		}
+
+		return function
+	case "exp":
+		function := elastic.NewExponentialDecayFunction().FieldName(sf.Field).Origin(sf.Origin).Scale(sf.Scale)
+
+		if sf.Offset != nil {
+			function = function.Offset(sf.Offset)
+		}
+
+		if sf.Decay > 0 {
+			function = function.Decay(sf.Decay)
+		}
+
+		return function
+	case "script_score":
+		return elastic.NewScriptFunction(elastic.NewScript(sf.Script).Lang("painless").Params(sf.Params))
+	case "random_score":
+		return elastic.NewRandomFunction().Seed(sf.Seed).Field("_seq_no")
+	}
+
+	return elastic.NewWeightFactorFunction(sf.Weight)
+}
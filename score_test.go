@@ -0,0 +1,106 @@
+package golastic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScoreValidation(t *testing.T) {
+	missing := 1.0
+
+	builder := new(Builder)
+	builder.Score().
+		FieldValueFactor("likes", 1.2, "log1p", &missing).
+		Gauss("published_at", "now", "10d", nil, 0.5).
+		Exp("location", GeoPoint{Lat: 40.71, Lon: -74.0}, "2km", "1km", 0).
+		Weight(3, func(filter *Builder) {
+			filter.Filter("featured", "=", "yes")
+		}).
+		ScriptScore("_score * doc['likes'].value", nil).
+		RandomScore(42).
+		ScoreMode("sum").
+		BoostMode("multiply")
+
+	if got := builder.score.validate(); got != nil {
+		t.Error("Expected no errors but got ", got)
+	}
+
+	builder = new(Builder)
+	builder.Score().FieldValueFactor("likes", 1.2, "cube", nil)
+
+	if got := builder.score.validate(); got == nil {
+		t.Error("Expected errors but got ", got)
+	}
+
+	builder = new(Builder)
+	builder.Score().Linear("published_at", nil, "10d", nil, 0.5)
+
+	if got := builder.score.validate(); got == nil {
+		t.Error("Expected errors but got ", got)
+	}
+
+	builder = new(Builder)
+	builder.Score().Weight(2, func(filter *Builder) {
+		filter.Filter("featured", "<>", "yes")
+	})
+
+	if got := builder.score.validate(); got == nil {
+		t.Error("Expected errors but got ", got)
+	}
+
+	builder = new(Builder)
+	builder.Score().RandomScore(42).ScoreMode("median")
+
+	if got := builder.score.validate(); got == nil {
+		t.Error("Expected errors but got ", got)
+	}
+}
+
+func TestScoreQuery(t *testing.T) {
+	builder := new(Builder)
+	builder.Where("status", "=", "published")
+	builder.Score().RandomScore(42).BoostMode("replace")
+
+	source, err := builder.searchQuery().Source()
+
+	if err != nil {
+		t.Error("Expected no errors but got ", err)
+	}
+
+	container, err := toGabsContainer(source)
+
+	if err != nil {
+		t.Error("Expected no errors but got ", err)
+	}
+
+	assert.Equal(t, "replace", container.Path("function_score.boost_mode").Data().(string))
+	functions, err := container.Path("function_score.functions").Children()
+
+	if err != nil {
+		t.Error("Expected no errors but got ", err)
+	}
+
+	assert.Equal(t, 1, len(functions))
+	assert.Equal(t, float64(42), functions[0].Path("random_score.seed").Data().(float64))
+	assert.NotNil(t, container.Path("function_score.query.bool.must").Data())
+
+	missing := 1.0
+
+	builder = new(Builder)
+	builder.Score().FieldValueFactor("likes", 1.2, "log", nil).FieldValueFactor("views", 1, "ln", &missing)
+
+	source, err = builder.searchQuery().Source()
+
+	assert.Nil(t, err)
+
+	container, err = toGabsContainer(source)
+
+	assert.Nil(t, err)
+
+	functions, err = container.Path("function_score.functions").Children()
+
+	assert.Nil(t, err)
+	assert.False(t, functions[0].Exists("field_value_factor", "missing"))
+	assert.Equal(t, float64(1), functions[1].Path("field_value_factor.missing").Data())
+}
@@ -0,0 +1,46 @@
+package golastic
+
+import "errors"
+
+// StatsField represents the sub-builder in charge of tuning the extended_stats aggregation of a Stats field
+type StatsField struct {
+	field string
+	path  *string
+}
+
+// StatsField returns the sub-builder for setting the options of the given Stats field
+func (b *Builder) StatsField(field string) *StatsField {
+	if b.statsFields == nil {
+		b.statsFields = map[string]*StatsField{}
+	}
+
+	if _, exists := b.statsFields[field]; !exists {
+		b.statsFields[field] = &StatsField{field: field}
+	}
+
+	return b.statsFields[field]
+}
+
+// Nested overrides the nested path guessed from the object.property notation of the field, an
+// empty path computes the stats of multi-fields such as "price.scaled" on the root documents
+func (sf *StatsField) Nested(path string) *StatsField {
+	sf.path = &path
+
+	return sf
+}
+
+func (sf *StatsField) validate(fields []string) error {
+	if !inSlice(sf.field, fields...) {
+		return errors.New("The field " + sf.field + " is not part of the Stats clause.")
+	}
+
+	return validateNestedPath(sf.field, sf.path)
+}
+
+func (b *Builder) statsPath(field string) string {
+	if options, exists := b.statsFields[field]; exists && options.path != nil {
+		return *options.path
+	}
+
+	return nestedPath(field)
+}
@@ -0,0 +1,239 @@
+package golastic
+
+import (
+	"errors"
+
+	elastic "github.com/alejandro-carstens/elasticfork"
+)
+
+// Suggestion represents the struct in charge of configuring
+// a term, phrase or completion suggester
+type Suggestion struct {
+	suggestType      string
+	name             string
+	field            string
+	text             string
+	size             *int
+	analyzer         string
+	suggestMode      string
+	maxErrors        *float64
+	confidence       *float64
+	highlightPreTag  string
+	highlightPostTag string
+	fuzziness        interface{}
+	skipDuplicates   *bool
+	contexts         map[string][]string
+}
+
+// Size sets the maximum number of options to be returned
+func (s *Suggestion) Size(size int) *Suggestion {
+	s.size = &size
+
+	return s
+}
+
+// Analyzer sets the analyzer used to analyze the suggested text
+func (s *Suggestion) Analyzer(analyzer string) *Suggestion {
+	s.analyzer = analyzer
+
+	return s
+}
+
+// SuggestMode sets which suggestions are returned by a term suggester (missing, popular or always)
+func (s *Suggestion) SuggestMode(mode string) *Suggestion {
+	s.suggestMode = mode
+
+	return s
+}
+
+// MaxErrors sets the maximum percentage of misspelled terms for a phrase suggester
+func (s *Suggestion) MaxErrors(maxErrors float64) *Suggestion {
+	s.maxErrors = &maxErrors
+
+	return s
+}
+
+// Confidence sets the threshold that a phrase suggester option needs to
+// meet relative to the score of the suggested text in order to be returned
+func (s *Suggestion) Confidence(confidence float64) *Suggestion {
+	s.confidence = &confidence
+
+	return s
+}
+
+// Highlight sets the tags that wrap the corrected terms of a phrase suggester option
+func (s *Suggestion) Highlight(preTag string, postTag string) *Suggestion {
+	s.highlightPreTag = preTag
+	s.highlightPostTag = postTag
+
+	return s
+}
+
+// Fuzzy enables fuzzy matching for a completion suggester with
+// the given fuzziness, i.e. "AUTO", 1 or 2
+func (s *Suggestion) Fuzzy(fuzziness interface{}) *Suggestion {
+	s.fuzziness = fuzziness
+
+	return s
+}
+
+// SkipDuplicates sets whether a completion suggester filters out duplicate options
+func (s *Suggestion) SkipDuplicates(skip bool) *Suggestion {
+	s.skipDuplicates = &skip
+
+	return s
+}
+
+// Context restricts a completion suggester to the given values of a category context
+func (s *Suggestion) Context(name string, values ...string) *Suggestion {
+	if s.contexts == nil {
+		s.contexts = map[string][]string{}
+	}
+
+	s.contexts[name] = append(s.contexts[name], values...)
+
+	return s
+}
+
+func (s *Suggestion) validate() error {
+	if len(s.name) == 0 {
+		return errors.New("name cannot be empty")
+	}
+
+	if len(s.field) == 0 {
+		return errors.New("field cannot be empty")
+	}
+
+	if s.size != nil && *s.size <= 0 {
+		return errors.New("The suggestion size needs to be greater than 0.")
+	}
+
+	if len(s.suggestMode) > 0 {
+		if s.suggestType != "term" {
+			return errors.New("The suggest mode is only supported by term suggesters.")
+		}
+
+		if !inSlice(s.suggestMode, "missing", "popular", "always") {
+			return errors.New("The suggest mode is invalid.")
+		}
+	}
+
+	if s.suggestType != "phrase" && (s.maxErrors != nil || s.confidence != nil || len(s.highlightPreTag) > 0) {
+		return errors.New("Max errors, confidence and highlight are only supported by phrase suggesters.")
+	}
+
+	if s.suggestType != "completion" && (s.fuzziness != nil || s.skipDuplicates != nil || len(s.contexts) > 0) {
+		return errors.New("Fuzzy, skip duplicates and contexts are only supported by completion suggesters.")
+	}
+
+	return nil
+}
+
+func (s *Suggestion) suggester() elastic.Suggester {
+	switch s.suggestType {
+	case "term":
+		suggester := elastic.NewTermSuggester(s.name).Field(s.field).Text(s.text)
+
+		if s.size != nil {
+			suggester = suggester.Size(*s.size)
+		}
+
+		if len(s.analyzer) > 0 {
+			suggester = suggester.Analyzer(s.analyzer)
+		}
+
+		if len(s.suggestMode) > 0 {
+			suggester = suggester.SuggestMode(s.suggestMode)
+		}
+
+		return suggester
+	case "phrase":
+		suggester := elastic.NewPhraseSuggester(s.name).Field(s.field).Text(s.text)
+
+		if s.size != nil {
+			suggester = suggester.Size(*s.size)
+		}
+
+		if len(s.analyzer) > 0 {
+			suggester = suggester.Analyzer(s.analyzer)
+		}
+
+		if s.maxErrors != nil {
+			suggester = suggester.MaxErrors(*s.maxErrors)
+		}
+
+		if s.confidence != nil {
+			suggester = suggester.Confidence(*s.confidence)
+		}
+
+		if len(s.highlightPreTag) > 0 || len(s.highlightPostTag) > 0 {
+			suggester = suggester.Highlight(s.highlightPreTag, s.highlightPostTag)
+		}
+
+		return suggester
+	}
+
+	suggester := elastic.NewCompletionSuggester(s.name).Field(s.field).Prefix(s.text)
+
+	if s.size != nil {
+		suggester = suggester.Size(*s.size)
+	}
+
+	if len(s.analyzer) > 0 {
+		suggester = suggester.Analyzer(s.analyzer)
+	}
+
+	if s.fuzziness != nil {
+		suggester = suggester.Fuzziness(s.fuzziness)
+	}
+
+	if s.skipDuplicates != nil {
+		suggester = suggester.SkipDuplicates(*s.skipDuplicates)
+	}
+
+	for name, values := range s.contexts {
+		suggester = suggester.ContextQuery(elastic.NewSuggesterCategoryQuery(name, values...))
+	}
+
+	return suggester
+}
+
+func processSuggestions(suggest elastic.SearchSuggest) SuggestResponses {
+	suggestResponses := SuggestResponses{}
+
+	for name, suggestions := range suggest {
+		suggestResponses[name] = []*SuggestResponse{}
+
+		for _, suggestion := range suggestions {
+			suggestResponse := &SuggestResponse{
+				Text:    suggestion.Text,
+				Offset:  suggestion.Offset,
+				Length:  suggestion.Length,
+				Options: []*SuggestOption{},
+			}
+
+			for _, option := range suggestion.Options {
+				score := option.Score
+
+				if score == 0 {
+					score = option.ScoreUnderscore
+				}
+
+				suggestResponse.Options = append(suggestResponse.Options, &SuggestOption{
+					Text:         option.Text,
+					Score:        score,
+					Freq:         option.Freq,
+					Highlighted:  option.Highlighted,
+					CollateMatch: option.CollateMatch,
+					Id:           option.Id,
+					Index:        option.Index,
+					Source:       option.Source,
+				})
+			}
+
+			suggestResponses[name] = append(suggestResponses[name], suggestResponse)
+		}
+	}
+
+	return suggestResponses
+}
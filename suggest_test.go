@@ -0,0 +1,52 @@
+package golastic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuggestionValidation(t *testing.T) {
+	builder := new(Builder)
+	builder.SuggestTerm("spelling", "description", "avatr").SuggestMode("popular").Size(3)
+	builder.SuggestPhrase("did_you_mean", "description", "avatr").MaxErrors(2).Confidence(1).Highlight("<em>", "</em>")
+	builder.SuggestCompletion("autocomplete", "suggest", "ava").Fuzzy("AUTO").SkipDuplicates(true).Context("genre", "drama")
+
+	for _, suggestion := range builder.suggestions {
+		if got := suggestion.validate(); got != nil {
+			t.Error("Expected no errors but got ", got)
+		}
+	}
+
+	invalid := []*Suggestion{
+		new(Builder).SuggestTerm("", "description", "avatr"),
+		new(Builder).SuggestTerm("spelling", "", "avatr"),
+		new(Builder).SuggestTerm("spelling", "description", "avatr").Size(0),
+		new(Builder).SuggestTerm("spelling", "description", "avatr").SuggestMode("sometimes"),
+		new(Builder).SuggestPhrase("did_you_mean", "description", "avatr").SuggestMode("popular"),
+		new(Builder).SuggestTerm("spelling", "description", "avatr").Confidence(1),
+		new(Builder).SuggestPhrase("did_you_mean", "description", "avatr").Fuzzy(1),
+	}
+
+	for _, suggestion := range invalid {
+		if got := suggestion.validate(); got == nil {
+			t.Error("Expected errors but got ", got)
+		}
+	}
+}
+
+func TestSuggester(t *testing.T) {
+	builder := new(Builder)
+	builder.SuggestCompletion("autocomplete", "suggest", "ava").Fuzzy("AUTO").Size(5)
+
+	source, err := builder.suggestions[0].suggester().Source(false)
+
+	assert.Nil(t, err)
+
+	container, err := toGabsContainer(source)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "ava", container.Path("prefix").Data())
+	assert.Equal(t, "suggest", container.Path("completion.field").Data())
+	assert.Equal(t, float64(5), container.Path("completion.size").Data())
+}
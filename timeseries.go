@@ -0,0 +1,473 @@
+package golastic
+
+import (
+	"errors"
+	sorter "sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Jeffail/gabs"
+)
+
+var calendarIntervals = map[string]string{
+	"second":  "second",
+	"1s":      "second",
+	"minute":  "minute",
+	"1m":      "minute",
+	"hour":    "hour",
+	"1h":      "hour",
+	"day":     "day",
+	"1d":      "day",
+	"week":    "week",
+	"1w":      "week",
+	"month":   "month",
+	"1M":      "month",
+	"quarter": "quarter",
+	"1q":      "quarter",
+	"year":    "year",
+	"1y":      "year",
+}
+
+var fixedIntervalUnits = map[string]time.Duration{
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+	"d":  24 * time.Hour,
+}
+
+// TimeSeries represents the sub-builder in charge of retrieving the ordered points
+// of a date histogram for the documents matching the query, optionally split by a field
+type TimeSeries struct {
+	field      string
+	interval   string
+	format     string
+	timeZone   string
+	bounds     []time.Time
+	fillGaps   bool
+	zeroPad    bool
+	splitField string
+	splitSize  int
+	metrics    []*Aggregation
+	builder    *Builder
+}
+
+// TimeSeries returns a time series sub-builder which buckets the values of a date field by the
+// given interval, i.e. "day" or "12h", the metrics are named after their type and field, i.e. "avg_price"
+func (b *Builder) TimeSeries(field string, interval string, metrics ...*Aggregation) *TimeSeries {
+	ts := &TimeSeries{field: field, interval: interval, builder: b}
+
+	for _, metric := range metrics {
+		name := metric.aggType
+
+		if len(metric.field) > 0 {
+			name = name + "_" + metric.field
+		}
+
+		ts.Metric(name, metric)
+	}
+
+	return ts
+}
+
+// Metric computes the given metric aggregation for each point of the series under the given name
+func (ts *TimeSeries) Metric(name string, aggregation *Aggregation) *TimeSeries {
+	aggregation.name = name
+
+	ts.metrics = append(ts.metrics, aggregation)
+
+	return ts
+}
+
+// Format sets the format of the dates returned as the key as string of each point
+func (ts *TimeSeries) Format(format string) *TimeSeries {
+	ts.format = format
+
+	return ts
+}
+
+// TimeZone sets the time zone used for bucketing and gap filling, i.e. "-05:00" or "America/New_York"
+func (ts *TimeSeries) TimeZone(timeZone string) *TimeSeries {
+	ts.timeZone = timeZone
+
+	return ts
+}
+
+// Bounds forces the series to cover the period from min to max even if there are no documents for it
+func (ts *TimeSeries) Bounds(min time.Time, max time.Time) *TimeSeries {
+	ts.bounds = []time.Time{min, max}
+
+	return ts
+}
+
+// FillGaps inserts a point with a count of 0 for every missing interval
+// so that every series shares the same timestamps from its first to its last point
+func (ts *TimeSeries) FillGaps() *TimeSeries {
+	ts.fillGaps = true
+
+	return ts
+}
+
+// ZeroPad fills the gaps and sets the single value metrics lacking a value to 0
+func (ts *TimeSeries) ZeroPad() *TimeSeries {
+	ts.zeroPad = true
+
+	return ts.FillGaps()
+}
+
+// SplitBy returns one series for each of the size most frequent values of the given field
+func (ts *TimeSeries) SplitBy(field string, size int) *TimeSeries {
+	ts.splitField = field
+	ts.splitSize = size
+
+	return ts
+}
+
+// Series retrieves the series, a single series with a nil key is returned unless SplitBy was specified.
+// Only the query of the builder is sent, its other aggregations, facets and min score are left out
+func (ts *TimeSeries) Series() ([]*TimeSeriesResponse, error) {
+	aggregation := ts.aggregation()
+
+	if err := ts.validate(aggregation); err != nil {
+		return nil, err
+	}
+
+	source, err := ts.builder.aggregationSource()
+
+	if err != nil {
+		return nil, err
+	}
+
+	source = source.Aggregation(aggregation.name, aggregation.aggregation())
+
+	response, err := ts.builder.searchService().SearchSource(source).Do(ts.builder.context)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Aggregations == nil {
+		return nil, errors.New("No aggregations returned")
+	}
+
+	raw, found := response.Aggregations[aggregation.name]
+
+	if !found {
+		return nil, errors.New("No aggregations returned")
+	}
+
+	container, err := gabs.ParseJSON(raw)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ts.parse(aggregation, container)
+}
+
+func (ts *TimeSeries) aggregation() *Aggregation {
+	histogram := DateHistogramAgg(ts.field, ts.interval)
+	histogram.name = "time_series"
+
+	if len(ts.format) > 0 {
+		histogram.Format(ts.format)
+	}
+
+	if len(ts.timeZone) > 0 {
+		histogram.TimeZone(ts.timeZone)
+	}
+
+	if len(ts.bounds) == 2 {
+		histogram.MinDocCount(0).ExtendedBounds(toMilliseconds(ts.bounds[0]), toMilliseconds(ts.bounds[1]))
+	}
+
+	histogram.subAggregations = ts.metrics
+
+	if len(ts.splitField) == 0 {
+		return histogram
+	}
+
+	split := TermsAgg(ts.splitField).Size(ts.splitSize).SubAggregation(histogram.name, histogram)
+	split.name = "time_series_split"
+
+	return split
+}
+
+func (ts *TimeSeries) validate(aggregation *Aggregation) error {
+	if _, _, err := parseInterval(ts.interval); err != nil {
+		return err
+	}
+
+	if _, err := loadLocation(ts.timeZone); err != nil {
+		return err
+	}
+
+	if len(ts.bounds) == 2 && ts.bounds[0].After(ts.bounds[1]) {
+		return errors.New("The min bound cannot be after the max bound.")
+	}
+
+	for _, metric := range ts.metrics {
+		if metric.isBucketAggregation() {
+			return errors.New("The metric " + metric.name + " needs to be a metric aggregation.")
+		}
+	}
+
+	return aggregation.validate()
+}
+
+func (ts *TimeSeries) parse(aggregation *Aggregation, container *gabs.Container) ([]*TimeSeriesResponse, error) {
+	location, err := loadLocation(ts.timeZone)
+
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := aggregation.parse(container)
+
+	if err != nil {
+		return nil, err
+	}
+
+	series := []*TimeSeriesResponse{}
+
+	if len(ts.splitField) == 0 {
+		series = append(series, ts.series(nil, response, location))
+	} else {
+		for _, bucket := range response.Buckets {
+			series = append(series, ts.series(bucket.Key, bucket.Items["time_series"], location))
+		}
+	}
+
+	if ts.fillGaps {
+		ts.fill(series, location)
+	}
+
+	return series, nil
+}
+
+func (ts *TimeSeries) series(key interface{}, histogram *AggregationResponse, location *time.Location) *TimeSeriesResponse {
+	series := &TimeSeriesResponse{Key: key, Points: []*TimeSeriesPoint{}}
+
+	if histogram == nil {
+		return series
+	}
+
+	for _, bucket := range histogram.Buckets {
+		milliseconds, _ := toFloat(bucket.Key)
+
+		series.DocCount = series.DocCount + bucket.DocCount
+		series.Points = append(series.Points, &TimeSeriesPoint{
+			Timestamp:   fromMilliseconds(int64(milliseconds), location),
+			KeyAsString: bucket.KeyAsString,
+			Count:       bucket.DocCount,
+			Metrics:     bucket.Items,
+		})
+	}
+
+	return series
+}
+
+func (ts *TimeSeries) fill(series []*TimeSeriesResponse, location *time.Location) {
+	unit, duration, _ := parseInterval(ts.interval)
+	timestamps := map[int64]time.Time{}
+
+	for _, s := range series {
+		for _, point := range s.Points {
+			timestamps[toMilliseconds(point.Timestamp)] = point.Timestamp
+		}
+	}
+
+	bounds := []time.Time{}
+
+	for _, bound := range ts.bounds {
+		bounds = append(bounds, floorTimestamp(bound.In(location), unit, duration))
+	}
+
+	for _, timestamp := range timestamps {
+		bounds = append(bounds, timestamp)
+	}
+
+	if len(bounds) == 0 {
+		return
+	}
+
+	sorter.Slice(bounds, func(i, j int) bool { return bounds[i].Before(bounds[j]) })
+
+	for timestamp := bounds[0]; !timestamp.After(bounds[len(bounds)-1]); timestamp = stepTimestamp(timestamp, unit, duration) {
+		if _, found := timestamps[toMilliseconds(timestamp)]; !found {
+			timestamps[toMilliseconds(timestamp)] = timestamp
+		}
+	}
+
+	timeline := []int64{}
+
+	for milliseconds := range timestamps {
+		timeline = append(timeline, milliseconds)
+	}
+
+	sorter.Slice(timeline, func(i, j int) bool { return timeline[i] < timeline[j] })
+
+	for _, s := range series {
+		points := map[int64]*TimeSeriesPoint{}
+
+		for _, point := range s.Points {
+			points[toMilliseconds(point.Timestamp)] = point
+		}
+
+		s.Points = []*TimeSeriesPoint{}
+
+		for _, milliseconds := range timeline {
+			point, found := points[milliseconds]
+
+			if !found {
+				point = &TimeSeriesPoint{Timestamp: timestamps[milliseconds], Metrics: AggregationResponses{}}
+			}
+
+			if ts.zeroPad {
+				ts.pad(point)
+			}
+
+			s.Points = append(s.Points, point)
+		}
+	}
+}
+
+func (ts *TimeSeries) pad(point *TimeSeriesPoint) {
+	if point.Metrics == nil {
+		point.Metrics = AggregationResponses{}
+	}
+
+	for _, metric := range ts.metrics {
+		if _, found := point.Metrics[metric.name]; !found {
+			point.Metrics[metric.name] = &AggregationResponse{}
+		}
+
+		if point.Metrics[metric.name].Value == nil && metric.isSingleValueAggregation() {
+			zero := float64(0)
+
+			point.Metrics[metric.name].Value = &zero
+		}
+	}
+}
+
+// parseInterval returns the calendar unit of the interval, or its duration when it is a fixed interval
+func parseInterval(interval string) (string, time.Duration, error) {
+	if unit, valid := calendarIntervals[interval]; valid {
+		return unit, 0, nil
+	}
+
+	for _, suffix := range []string{"ms", "s", "m", "h", "d"} {
+		if !strings.HasSuffix(interval, suffix) {
+			continue
+		}
+
+		value, err := strconv.Atoi(strings.TrimSuffix(interval, suffix))
+
+		if err != nil || value <= 0 {
+			break
+		}
+
+		return "", time.Duration(value) * fixedIntervalUnits[suffix], nil
+	}
+
+	return "", 0, errors.New("The interval " + interval + " is invalid.")
+}
+
+// loadLocation resolves a time zone given either as an offset, i.e. "-05:00", or as a name
+func loadLocation(timeZone string) (*time.Location, error) {
+	if len(timeZone) == 0 {
+		return time.UTC, nil
+	}
+
+	if len(timeZone) == 6 && (timeZone[0] == '+' || timeZone[0] == '-') && timeZone[3] == ':' {
+		hours, hoursErr := strconv.Atoi(timeZone[1:3])
+		minutes, minutesErr := strconv.Atoi(timeZone[4:])
+
+		if hoursErr != nil || minutesErr != nil {
+			return nil, errors.New("The time zone " + timeZone + " is invalid.")
+		}
+
+		offset := hours*3600 + minutes*60
+
+		if timeZone[0] == '-' {
+			offset = -offset
+		}
+
+		return time.FixedZone(timeZone, offset), nil
+	}
+
+	location, err := time.LoadLocation(timeZone)
+
+	if err != nil {
+		return nil, errors.New("The time zone " + timeZone + " is invalid.")
+	}
+
+	return location, nil
+}
+
+func floorTimestamp(timestamp time.Time, unit string, duration time.Duration) time.Time {
+	year, month, day := timestamp.Date()
+	location := timestamp.Location()
+
+	switch unit {
+	case "second":
+		return time.Date(year, month, day, timestamp.Hour(), timestamp.Minute(), timestamp.Second(), 0, location)
+	case "minute":
+		return time.Date(year, month, day, timestamp.Hour(), timestamp.Minute(), 0, 0, location)
+	case "hour":
+		return time.Date(year, month, day, timestamp.Hour(), 0, 0, 0, location)
+	case "day":
+		return time.Date(year, month, day, 0, 0, 0, 0, location)
+	case "week":
+		return time.Date(year, month, day-(int(timestamp.Weekday())+6)%7, 0, 0, 0, 0, location)
+	case "month":
+		return time.Date(year, month, 1, 0, 0, 0, 0, location)
+	case "quarter":
+		return time.Date(year, ((month-1)/3)*3+1, 1, 0, 0, 0, 0, location)
+	case "year":
+		return time.Date(year, 1, 1, 0, 0, 0, 0, location)
+	}
+
+	_, offset := timestamp.Zone()
+	milliseconds := toMilliseconds(timestamp) + int64(offset)*1000
+	interval := int64(duration / time.Millisecond)
+	remainder := milliseconds % interval
+
+	if remainder < 0 {
+		remainder = remainder + interval
+	}
+
+	return fromMilliseconds(milliseconds-remainder-int64(offset)*1000, location)
+}
+
+func stepTimestamp(timestamp time.Time, unit string, duration time.Duration) time.Time {
+	switch unit {
+	case "second":
+		return timestamp.Add(time.Second)
+	case "minute":
+		return timestamp.Add(time.Minute)
+	case "hour":
+		return timestamp.Add(time.Hour)
+	case "day":
+		return timestamp.AddDate(0, 0, 1)
+	case "week":
+		return timestamp.AddDate(0, 0, 7)
+	case "month":
+		return timestamp.AddDate(0, 1, 0)
+	case "quarter":
+		return timestamp.AddDate(0, 3, 0)
+	case "year":
+		return timestamp.AddDate(1, 0, 0)
+	}
+
+	return timestamp.Add(duration)
+}
+
+func toMilliseconds(timestamp time.Time) int64 {
+	return timestamp.UnixNano() / int64(time.Millisecond)
+}
+
+func fromMilliseconds(milliseconds int64, location *time.Location) time.Time {
+	return time.Unix(0, milliseconds*int64(time.Millisecond)).In(location)
+}
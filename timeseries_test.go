@@ -0,0 +1,147 @@
+package golastic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Jeffail/gabs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeSeriesValidation(t *testing.T) {
+	from := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2019, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	valid := []*TimeSeries{
+		new(Builder).TimeSeries("created_at", "day"),
+		new(Builder).TimeSeries("created_at", "12h", AvgAgg("price"), SumAgg("amount")).TimeZone("-05:00"),
+		new(Builder).TimeSeries("created_at", "1M").TimeZone("America/New_York").Bounds(from, to).ZeroPad(),
+		new(Builder).TimeSeries("created_at", "week", SumAgg("amount")).
+			Metric("growth", DerivativeAgg("sum_amount")).
+			SplitBy("status", 5),
+	}
+
+	for _, ts := range valid {
+		if got := ts.validate(ts.aggregation()); got != nil {
+			t.Error("Expected no errors but got ", got)
+		}
+	}
+
+	invalid := []*TimeSeries{
+		new(Builder).TimeSeries("", "day"),
+		new(Builder).TimeSeries("created_at", ""),
+		new(Builder).TimeSeries("created_at", "fortnight"),
+		new(Builder).TimeSeries("created_at", "0h"),
+		new(Builder).TimeSeries("created_at", "day").TimeZone("Mars/Olympus_Mons"),
+		new(Builder).TimeSeries("created_at", "day").Bounds(to, from),
+		new(Builder).TimeSeries("created_at", "day", TermsAgg("status")),
+		new(Builder).TimeSeries("created_at", "day", SumAgg("amount"), SumAgg("amount")),
+		new(Builder).TimeSeries("created_at", "day").Metric("growth", DerivativeAgg("sum_amount")),
+		new(Builder).TimeSeries("created_at", "day").SplitBy("status", 0),
+	}
+
+	for _, ts := range invalid {
+		if got := ts.validate(ts.aggregation()); got == nil {
+			t.Error("Expected errors but got ", got)
+		}
+	}
+}
+
+func TestTimeSeriesSource(t *testing.T) {
+	ts := new(Builder).TimeSeries("created_at", "day", AvgAgg("price")).
+		TimeZone("-05:00").
+		Format("yyyy-MM-dd").
+		Bounds(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 31, 0, 0, 0, 0, time.UTC)).
+		SplitBy("status", 3)
+
+	data, err := ts.aggregation().aggregation().Source()
+
+	assert.Nil(t, err)
+
+	container, err := toGabsContainer(data)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "status", container.Path("terms.field").Data())
+	assert.Equal(t, float64(3), container.Path("terms.size").Data())
+
+	histogram := container.Search("aggregations", "time_series", "date_histogram")
+
+	assert.Equal(t, "created_at", histogram.Path("field").Data())
+	assert.Equal(t, "day", histogram.Path("interval").Data())
+	assert.Equal(t, "-05:00", histogram.Path("time_zone").Data())
+	assert.Equal(t, "yyyy-MM-dd", histogram.Path("format").Data())
+	assert.Equal(t, float64(0), histogram.Path("min_doc_count").Data())
+	assert.Equal(t, float64(1546300800000), histogram.Path("extended_bounds.min").Data())
+	assert.Equal(t, "price", container.Path("aggregations.time_series.aggregations.avg_price.avg.field").Data())
+}
+
+func TestTimeSeriesParsing(t *testing.T) {
+	ts := new(Builder).TimeSeries("created_at", "day", AvgAgg("price")).
+		TimeZone("-05:00").
+		Bounds(time.Date(2019, 1, 1, 12, 0, 0, 0, time.UTC), time.Date(2019, 1, 5, 12, 0, 0, 0, time.UTC)).
+		ZeroPad().
+		SplitBy("status", 2)
+
+	container, err := gabs.ParseJSON([]byte(`{
+		"buckets": [
+			{"key": "paid", "doc_count": 4, "time_series": {"buckets": [
+				{"key": 1546405200000, "key_as_string": "2019-01-02", "doc_count": 3, "avg_price": {"value": 10}},
+				{"key": 1546578000000, "key_as_string": "2019-01-04", "doc_count": 1, "avg_price": {"value": 30}}
+			]}},
+			{"key": "refunded", "doc_count": 1, "time_series": {"buckets": [
+				{"key": 1546491600000, "key_as_string": "2019-01-03", "doc_count": 1, "avg_price": {"value": null}}
+			]}}
+		]
+	}`))
+
+	assert.Nil(t, err)
+
+	series, err := ts.parse(ts.aggregation(), container)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(series))
+	assert.Equal(t, "paid", series[0].Key)
+	assert.Equal(t, 4, series[0].DocCount)
+
+	location, _ := loadLocation("-05:00")
+
+	for _, s := range series {
+		assert.Equal(t, 5, len(s.Points))
+
+		for i, point := range s.Points {
+			assert.Equal(t, time.Date(2019, 1, 1+i, 0, 0, 0, 0, location).Unix(), point.Timestamp.Unix())
+			assert.NotNil(t, point.Metrics["avg_price"].Value)
+		}
+	}
+
+	assert.Equal(t, 0, series[0].Points[0].Count)
+	assert.Equal(t, 3, series[0].Points[1].Count)
+	assert.Equal(t, "2019-01-02", series[0].Points[1].KeyAsString)
+	assert.Equal(t, float64(10), *series[0].Points[1].Metrics["avg_price"].Value)
+	assert.Equal(t, float64(0), *series[0].Points[2].Metrics["avg_price"].Value)
+	assert.Equal(t, 1, series[1].Points[2].Count)
+	assert.Equal(t, float64(0), *series[1].Points[2].Metrics["avg_price"].Value)
+
+	unpadded := new(Builder).TimeSeries("created_at", "month")
+
+	container, err = gabs.ParseJSON([]byte(`{"buckets": [
+		{"key": 1546300800000, "doc_count": 2},
+		{"key": 1554076800000, "doc_count": 1}
+	]}`))
+
+	assert.Nil(t, err)
+
+	series, err = unpadded.parse(unpadded.aggregation(), container)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(series))
+	assert.Nil(t, series[0].Key)
+	assert.Equal(t, 2, len(series[0].Points))
+
+	series, err = unpadded.FillGaps().parse(unpadded.aggregation(), container)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(series[0].Points))
+	assert.Equal(t, time.March, series[0].Points[2].Timestamp.Month())
+	assert.Equal(t, 0, series[0].Points[2].Count)
+}
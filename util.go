@@ -1,11 +1,14 @@
 package golastic
 
 import (
+	"context"
 	"encoding/json"
+	"io"
 	"math"
 	"time"
 
 	"github.com/Jeffail/gabs"
+	elastic "github.com/alejandro-carstens/elasticfork"
 	"github.com/araddon/dateparse"
 )
 
@@ -115,3 +118,29 @@ func calculateChunkSize(length int) int {
 func calculateChunkCount(length int, chunkSize int) int {
 	return int(math.Ceil(float64(length) / float64(chunkSize)))
 }
+
+// scrollHits feeds every page of hits of the given scroll to the callback, so that no
+// hit is lost past the search size limit, and clears the scroll once it is exhausted
+func scrollHits(ctx context.Context, scroll *elastic.ScrollService, callback func(hits []*elastic.SearchHit) error) error {
+	defer scroll.Clear(ctx)
+
+	for {
+		response, err := scroll.Do(ctx)
+
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if response.Hits == nil || len(response.Hits.Hits) == 0 {
+			return nil
+		}
+
+		if err := callback(response.Hits.Hits); err != nil {
+			return err
+		}
+	}
+}